@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// AtomicPolicyFactory is a PolicyFactory whose underlying PolicyFactory, typically a Config,
+// can be replaced at runtime.  This allows retries to be reconfigured without recreating
+// any Runners, e.g. when a configuration file changes or via an administrative endpoint:
+//
+//	apf := retry.NewAtomicPolicyFactory(cfg)
+//	runner, _ := retry.NewRunner[int](retry.WithPolicyFactory[int](apf))
+//
+//	// later, during an incident:
+//	apf.Store(retry.Config{}) // no more retries
+//
+// Since a Runner creates a Policy at the start of each run, new runs use the PolicyFactory
+// stored at that time.  Runs already in progress keep the Policy they started with.
+//
+// The zero value of this type is ready to use, and behaves like an empty Config until a
+// PolicyFactory is stored.  An AtomicPolicyFactory must not be copied after first use.
+type AtomicPolicyFactory struct {
+	pf atomic.Pointer[PolicyFactory]
+}
+
+// NewAtomicPolicyFactory creates an AtomicPolicyFactory that initially delegates to pf.
+func NewAtomicPolicyFactory(pf PolicyFactory) *AtomicPolicyFactory {
+	apf := new(AtomicPolicyFactory)
+	apf.Store(pf)
+	return apf
+}
+
+// Load returns the current PolicyFactory.  If no PolicyFactory has been stored, or if
+// nil was stored, this method returns an empty Config.
+func (apf *AtomicPolicyFactory) Load() PolicyFactory {
+	if p := apf.pf.Load(); p != nil {
+		return *p
+	}
+
+	return Config{}
+}
+
+// Store replaces the current PolicyFactory.  A nil PolicyFactory creates policies that
+// never retry, like an empty Config.
+func (apf *AtomicPolicyFactory) Store(pf PolicyFactory) {
+	apf.Swap(pf)
+}
+
+// Swap replaces the current PolicyFactory, returning the previous one.  As with Load,
+// the previous PolicyFactory is an empty Config if none had been stored.
+func (apf *AtomicPolicyFactory) Swap(pf PolicyFactory) (old PolicyFactory) {
+	if pf == nil {
+		pf = Config{}
+	}
+
+	if p := apf.pf.Swap(&pf); p != nil {
+		return *p
+	}
+
+	return Config{}
+}
+
+// NewPolicy creates a Policy with the current PolicyFactory.
+func (apf *AtomicPolicyFactory) NewPolicy(ctx context.Context) Policy {
+	return apf.Load().NewPolicy(ctx)
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AtomicPolicyFactorySuite struct {
+	CommonSuite
+}
+
+func (suite *AtomicPolicyFactorySuite) TestZeroValue() {
+	var (
+		testCtx, _ = suite.testCtx()
+		apf        AtomicPolicyFactory
+	)
+
+	suite.Equal(Config{}, apf.Load())
+	suite.requireNever(apf.NewPolicy(testCtx))
+
+	suite.Equal(Config{}, apf.Swap(Config{Interval: time.Second}))
+	suite.Equal(Config{Interval: time.Second}, apf.Load())
+
+	apf.Store(nil)
+	suite.Equal(Config{}, apf.Load())
+}
+
+func (suite *AtomicPolicyFactorySuite) TestSwap() {
+	var (
+		testCtx, _ = suite.testCtx()
+		first      = Config{Interval: time.Second}
+		second     = Config{Interval: time.Minute, MaxRetries: 2}
+		apf        = NewAtomicPolicyFactory(first)
+	)
+
+	suite.Equal(first, apf.Load())
+	p := suite.requireConstant(apf.NewPolicy(testCtx))
+	suite.Equal(time.Second, p.interval)
+
+	suite.Equal(first, apf.Swap(second))
+	suite.Equal(second, apf.Load())
+	p = suite.requireConstant(apf.NewPolicy(testCtx))
+	suite.Equal(time.Minute, p.interval)
+	suite.Equal(2, p.maxRetries)
+}
+
+func (suite *AtomicPolicyFactorySuite) TestRunner() {
+	var (
+		testCtx, _ = suite.testCtx()
+		retryErr   = errors.New("should retry this")
+		apf        = NewAtomicPolicyFactory(Config{Interval: time.Second, MaxRetries: 3})
+		calls      int
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithPolicyFactory[int](apf),
+		)
+	)
+
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		calls++
+		if calls == 1 {
+			// the run already in progress must keep its policy
+			apf.Store(Config{})
+		}
+
+		return -1, retryErr
+	})
+
+	suite.ErrorIs(err, retryErr)
+	suite.Equal(4, calls)
+
+	calls = 0
+	_, err = runner.Run(testCtx, func(context.Context) (int, error) {
+		calls++
+		return -1, retryErr
+	})
+
+	suite.ErrorIs(err, retryErr)
+	suite.Equal(1, calls, "a new run must use the stored PolicyFactory")
+}
+
+func (suite *AtomicPolicyFactorySuite) TestConcurrency() {
+	var (
+		testCtx, _ = suite.testCtx()
+		apf        = NewAtomicPolicyFactory(Config{Interval: time.Second})
+		wg         sync.WaitGroup
+	)
+
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			apf.Store(Config{Interval: time.Duration(i+1) * time.Second})
+			p := apf.NewPolicy(testCtx)
+			defer p.Cancel()
+			d, ok := p.Next()
+			suite.True(ok)
+			suite.Positive(d)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestAtomicPolicyFactory(t *testing.T) {
+	suite.Run(t, new(AtomicPolicyFactorySuite))
+}
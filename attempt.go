@@ -22,10 +22,18 @@ type Attempt[V any] struct {
 	// represents a success.
 	Err error
 
+	// Category is the kind of failure that Err represents, as determined by the
+	// runner's Classifier.  If Err is nil, this field is CategoryNone.
+	Category Category
+
 	// Retries indicates the number of retries so far.  This field will
 	// be zero (0) on the initial attempt.
 	Retries int
 
+	// Elapsed is the amount of time since the first attempt started, measured
+	// when this attempt completed.
+	Elapsed time.Duration
+
 	// If another retry will be attempted, this is the duration that the
 	// runner will wait before the next retry.  If this field is zero (0),
 	// then no further retries will be attempted.
@@ -42,6 +50,28 @@ func (a Attempt[V]) Done() bool {
 	return a.Next <= 0 || a.Context.Err() != nil
 }
 
+// ShouldRetryAttempt is a predicate for determining whether a task's results
+// warrant a retry, given the entire Attempt rather than just the result and error.
+// See WithShouldRetryAttempt.
+type ShouldRetryAttempt[V any] func(Attempt[V]) bool
+
+// LimitRetries returns a ShouldRetryAttempt that applies the given predicate only while
+// fewer than n retries have happened.  This allows certain results to be retried fewer
+// times than the policy otherwise allows.
+func LimitRetries[V any](n int, sr ShouldRetry[V]) ShouldRetryAttempt[V] {
+	return func(a Attempt[V]) bool {
+		return a.Retries < n && sr(a.Result, a.Err)
+	}
+}
+
+// RetryWithin returns a ShouldRetryAttempt that applies the given predicate only while
+// the elapsed time of the attempts is less than d.
+func RetryWithin[V any](d time.Duration, sr ShouldRetry[V]) ShouldRetryAttempt[V] {
+	return func(a Attempt[V]) bool {
+		return a.Elapsed < d && sr(a.Result, a.Err)
+	}
+}
+
 // OnAttempt is an optional task callback that is invoked after each attempt
 // at invoking the task, including a successful one.
 //
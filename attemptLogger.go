@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"log/slog"
+)
+
+// The keys used by LogAttempts.  These are stable, so that log queries work the
+// same way across services.
+const (
+	// LogKeyAttempt is the 1-based attempt number, i.e. Attempt.Retries + 1.
+	LogKeyAttempt = "attempt"
+
+	// LogKeyError is the error returned by the attempt.  It is omitted for successes.
+	LogKeyError = "error"
+
+	// LogKeyCategory is the Category of the attempt's error.
+	LogKeyCategory = "category"
+
+	// LogKeyNext is the interval before the next retry.  It is only present when
+	// the outcome is LogOutcomeRetry.
+	LogKeyNext = "next"
+
+	// LogKeyElapsed is the time elapsed since the first attempt started.
+	LogKeyElapsed = "elapsed"
+
+	// LogKeyOutcome is the outcome of the attempt.  Its value is one of LogOutcomeSuccess,
+	// LogOutcomeRetry, or LogOutcomeGiveUp.
+	LogKeyOutcome = "outcome"
+)
+
+// The values for LogKeyOutcome.
+const (
+	// LogOutcomeSuccess indicates the last attempt, which did not return an error.
+	LogOutcomeSuccess = "success"
+
+	// LogOutcomeRetry indicates an attempt that will be retried.
+	LogOutcomeRetry = "retry"
+
+	// LogOutcomeGiveUp indicates the last attempt, which returned an error.
+	LogOutcomeGiveUp = "giveUp"
+)
+
+const (
+	// DefaultLogMessage is the log message used by LogAttempts if none is configured.
+	DefaultLogMessage = "task attempt"
+
+	// DefaultLogSuccessLevel is the level at which LogAttempts logs successes.
+	DefaultLogSuccessLevel = slog.LevelDebug
+
+	// DefaultLogRetryLevel is the level at which LogAttempts logs attempts that will be retried.
+	DefaultLogRetryLevel = slog.LevelWarn
+
+	// DefaultLogGiveUpLevel is the level at which LogAttempts logs the last, failed attempt.
+	DefaultLogGiveUpLevel = slog.LevelError
+)
+
+// LogOption is a configurable option for LogAttempts.
+type LogOption[V any] interface {
+	apply(*attemptLogger[V])
+}
+
+type logOptionFunc[V any] func(*attemptLogger[V])
+
+func (lof logOptionFunc[V]) apply(al *attemptLogger[V]) { lof(al) }
+
+// WithLogLevels sets the levels at which successes, retries, and give-ups are logged.
+func WithLogLevels[V any](success, retry, giveUp slog.Level) LogOption[V] {
+	return logOptionFunc[V](func(al *attemptLogger[V]) {
+		al.successLevel = success
+		al.retryLevel = retry
+		al.giveUpLevel = giveUp
+	})
+}
+
+// WithLogMessage sets the message for each log record.
+func WithLogMessage[V any](msg string) LogOption[V] {
+	return logOptionFunc[V](func(al *attemptLogger[V]) {
+		al.msg = msg
+	})
+}
+
+// WithResultAttrs appends hooks that add attributes from the attempt's result, such as
+// an HTTP status code.  This option can be applied repeatedly, and the set of hooks is
+// cumulative.  Each hook is invoked with every result, including the zero value for
+// failed attempts.
+func WithResultAttrs[V any](fns ...func(V) []slog.Attr) LogOption[V] {
+	return logOptionFunc[V](func(al *attemptLogger[V]) {
+		al.resultAttrs = append(al.resultAttrs, fns...)
+	})
+}
+
+type attemptLogger[V any] struct {
+	logger       *slog.Logger
+	msg          string
+	successLevel slog.Level
+	retryLevel   slog.Level
+	giveUpLevel  slog.Level
+	resultAttrs  []func(V) []slog.Attr
+}
+
+// outcome determines the level and the LogKeyOutcome value for an attempt.
+func (al *attemptLogger[V]) outcome(a Attempt[V]) (slog.Level, string) {
+	switch {
+	case !a.Done():
+		return al.retryLevel, LogOutcomeRetry
+
+	case a.Err != nil:
+		return al.giveUpLevel, LogOutcomeGiveUp
+
+	default:
+		return al.successLevel, LogOutcomeSuccess
+	}
+}
+
+func (al *attemptLogger[V]) onAttempt(a Attempt[V]) {
+	level, outcome := al.outcome(a)
+	if !al.logger.Enabled(a.Context, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String(LogKeyOutcome, outcome),
+		slog.Int(LogKeyAttempt, a.Retries+1),
+		slog.Duration(LogKeyElapsed, a.Elapsed),
+		slog.String(LogKeyCategory, a.Category.String()),
+	}
+
+	if a.Err != nil {
+		attrs = append(attrs, slog.String(LogKeyError, a.Err.Error()))
+	}
+
+	if outcome == LogOutcomeRetry {
+		attrs = append(attrs, slog.Duration(LogKeyNext, a.Next))
+	}
+
+	for _, f := range al.resultAttrs {
+		attrs = append(attrs, f(a.Result)...)
+	}
+
+	al.logger.LogAttrs(a.Context, level, al.msg, attrs...)
+}
+
+// LogAttempts creates an OnAttempt that logs each attempt to the given logger, using the
+// LogKey constants in this package.  If logger is nil, slog.Default() is used.
+//
+// An attempt that will be retried is logged at DefaultLogRetryLevel.  The last attempt is
+// logged at DefaultLogGiveUpLevel if it returned an error, and at DefaultLogSuccessLevel
+// otherwise.  Use WithLogLevels to change these levels.
+func LogAttempts[V any](logger *slog.Logger, opts ...LogOption[V]) OnAttempt[V] {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	al := &attemptLogger[V]{
+		logger:       logger,
+		msg:          DefaultLogMessage,
+		successLevel: DefaultLogSuccessLevel,
+		retryLevel:   DefaultLogRetryLevel,
+		giveUpLevel:  DefaultLogGiveUpLevel,
+	}
+
+	for _, o := range opts {
+		o.apply(al)
+	}
+
+	return al.onAttempt
+}
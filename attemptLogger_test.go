@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AttemptLoggerSuite struct {
+	CommonSuite
+
+	output *bytes.Buffer
+	logger *slog.Logger
+}
+
+func (suite *AttemptLoggerSuite) SetupTest() {
+	suite.output = new(bytes.Buffer)
+	suite.logger = slog.New(slog.NewJSONHandler(suite.output, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+}
+
+// records decodes each JSON log record that has been written so far.
+func (suite *AttemptLoggerSuite) records() (records []map[string]any) {
+	for _, line := range strings.Split(strings.TrimSpace(suite.output.String()), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+
+		var record map[string]any
+		suite.Require().NoError(json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return
+}
+
+func (suite *AttemptLoggerSuite) TestRunner() {
+	var (
+		testCtx, _ = suite.testCtx()
+		retryErr   = errors.New("should retry this")
+		task       = new(mockTask[int])
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithPolicyFactory[int](Config{
+				Interval: 5 * time.Second,
+			}),
+			WithOnAttempt(
+				LogAttempts(
+					suite.logger,
+					WithResultAttrs(func(v int) []slog.Attr {
+						return []slog.Attr{slog.Int("result", v)}
+					}),
+				),
+			),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, retryErr).Once()
+	task.ExpectMatch(suite.assertTestCtx, 123, nil).Once()
+	_, err := runner.Run(testCtx, task.Do)
+	suite.Require().NoError(err)
+
+	records := suite.records()
+	suite.Require().Len(records, 2)
+
+	suite.Equal("WARN", records[0]["level"])
+	suite.Equal(DefaultLogMessage, records[0]["msg"])
+	suite.Equal(LogOutcomeRetry, records[0][LogKeyOutcome])
+	suite.Equal(1.0, records[0][LogKeyAttempt])
+	suite.Equal("should retry this", records[0][LogKeyError])
+	suite.Equal("unknown", records[0][LogKeyCategory])
+	suite.Equal(float64(5*time.Second), records[0][LogKeyNext])
+	suite.Contains(records[0], LogKeyElapsed)
+	suite.Equal(-1.0, records[0]["result"])
+
+	suite.Equal("DEBUG", records[1]["level"])
+	suite.Equal(LogOutcomeSuccess, records[1][LogKeyOutcome])
+	suite.Equal(2.0, records[1][LogKeyAttempt])
+	suite.Equal("none", records[1][LogKeyCategory])
+	suite.NotContains(records[1], LogKeyError)
+	suite.NotContains(records[1], LogKeyNext)
+	suite.Equal(123.0, records[1]["result"])
+
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *AttemptLoggerSuite) TestGiveUp() {
+	var (
+		testCtx, _ = suite.testCtx()
+		fatalErr   = SetRetryable(errors.New("fatal"), false)
+		onAttempt  = LogAttempts(
+			suite.logger,
+			WithLogLevels[int](slog.LevelInfo, slog.LevelInfo, slog.LevelWarn),
+			WithLogMessage[int]("custom"),
+		)
+	)
+
+	onAttempt(Attempt[int]{
+		Context:  testCtx,
+		Err:      fatalErr,
+		Category: CategoryPermanent,
+		Retries:  2,
+		Elapsed:  time.Minute,
+	})
+
+	records := suite.records()
+	suite.Require().Len(records, 1)
+	suite.Equal("WARN", records[0]["level"])
+	suite.Equal("custom", records[0]["msg"])
+	suite.Equal(LogOutcomeGiveUp, records[0][LogKeyOutcome])
+	suite.Equal(3.0, records[0][LogKeyAttempt])
+	suite.Equal("fatal", records[0][LogKeyError])
+	suite.Equal("permanent", records[0][LogKeyCategory])
+	suite.Equal(float64(time.Minute), records[0][LogKeyElapsed])
+}
+
+func (suite *AttemptLoggerSuite) TestDisabled() {
+	var (
+		testCtx, _ = suite.testCtx()
+		hookCalled bool
+		onAttempt  = LogAttempts(
+			slog.New(slog.NewJSONHandler(suite.output, &slog.HandlerOptions{Level: slog.LevelInfo})),
+			WithResultAttrs(func(int) []slog.Attr {
+				hookCalled = true
+				return nil
+			}),
+		)
+	)
+
+	onAttempt(Attempt[int]{Context: testCtx, Result: 123})
+	suite.Empty(suite.output.String())
+	suite.False(hookCalled, "hooks must not be invoked for disabled levels")
+}
+
+func (suite *AttemptLoggerSuite) TestDefaultLogger() {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+	slog.SetDefault(suite.logger)
+
+	testCtx, _ := suite.testCtx()
+	LogAttempts[int](nil)(Attempt[int]{Context: testCtx, Result: 123})
+	suite.Len(suite.records(), 1)
+}
+
+func TestAttemptLogger(t *testing.T) {
+	suite.Run(t, new(AttemptLoggerSuite))
+}
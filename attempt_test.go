@@ -5,6 +5,7 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -59,6 +60,29 @@ func (suite *AttemptSuite) TestDone() {
 	}
 }
 
+func (suite *AttemptSuite) TestLimitRetries() {
+	var (
+		retryErr = errors.New("should retry this")
+		sra      = LimitRetries(2, RetryOnErrors[int](retryErr))
+	)
+
+	suite.True(sra(Attempt[int]{Err: retryErr, Retries: 0}))
+	suite.True(sra(Attempt[int]{Err: retryErr, Retries: 1}))
+	suite.False(sra(Attempt[int]{Err: retryErr, Retries: 2}))
+	suite.False(sra(Attempt[int]{Err: errors.New("other"), Retries: 0}))
+}
+
+func (suite *AttemptSuite) TestRetryWithin() {
+	var (
+		retryErr = errors.New("should retry this")
+		sra      = RetryWithin(30*time.Second, RetryOnErrors[int](retryErr))
+	)
+
+	suite.True(sra(Attempt[int]{Err: retryErr, Elapsed: 29 * time.Second}))
+	suite.False(sra(Attempt[int]{Err: retryErr, Elapsed: 30 * time.Second}))
+	suite.False(sra(Attempt[int]{Err: errors.New("other")}))
+}
+
 func TestAttempt(t *testing.T) {
 	suite.Run(t, new(AttemptSuite))
 }
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrBatchMismatch indicates that a BatchTask returned a different number of results
+// or errors than the number of items it was given.  This error is never retried.
+var ErrBatchMismatch = errors.New("batch task results do not match its items")
+
+// BatchTask is a task that processes a batch of items and reports success or failure
+// for each item individually.  The returned results and errors must either be nil or
+// have the same length as the items, with errs[i] reporting the outcome of items[i].
+// A nil error for an item indicates success.
+//
+// A non-nil error returned as the last value indicates that the batch as a whole failed,
+// e.g. due to a network error.  In that case, the per-item results and errors are ignored.
+type BatchTask[I, R any] func(context.Context, []I) (results []R, errs []error, err error)
+
+// BatchError reports the items of a batch that failed.  BatchError implements
+// ShouldRetryable, and indicates that a retry should happen if any of the failed
+// items may be retried.  A BatchError returned by RunBatch decides that with the
+// predicate passed to RunBatch.  Otherwise, DefaultTestErrorForRetry is used.
+type BatchError struct {
+	// Errs holds the error for each item in the original batch, in the same order
+	// as the items.  Items that succeeded have a nil error.
+	Errs []error
+
+	// retryItem is the predicate for item errors, which is nil for DefaultTestErrorForRetry
+	retryItem func(error) bool
+}
+
+// Failed returns the count of items in the batch that failed.
+func (be *BatchError) Failed() (n int) {
+	for _, err := range be.Errs {
+		if err != nil {
+			n++
+		}
+	}
+
+	return
+}
+
+func (be *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d batch items failed", be.Failed(), len(be.Errs))
+}
+
+// Unwrap returns the non-nil item errors, which allows errors.Is and errors.As
+// to examine each item's error.
+func (be *BatchError) Unwrap() (errs []error) {
+	for _, err := range be.Errs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return
+}
+
+// ShouldRetry returns true if at least one failed item may be retried.
+func (be *BatchError) ShouldRetry() bool {
+	retryItem := be.retryItem
+	if retryItem == nil {
+		retryItem = DefaultTestErrorForRetry
+	}
+
+	for _, err := range be.Errs {
+		if err != nil && retryItem(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// batchState holds the merged results of a batch across attempts.
+type batchState[I, R any] struct {
+	items     []I
+	task      BatchTask[I, R]
+	retryItem func(error) bool
+	results   []R
+	errs      []error
+
+	// pending holds the indices of the items to send in the next attempt
+	pending []int
+}
+
+func newBatchState[I, R any](items []I, task BatchTask[I, R], retryItem func(error) bool) *batchState[I, R] {
+	if retryItem == nil {
+		retryItem = DefaultTestErrorForRetry
+	}
+
+	bs := &batchState[I, R]{
+		items:     items,
+		task:      task,
+		retryItem: retryItem,
+		results:   make([]R, len(items)),
+		errs:      make([]error, len(items)),
+		pending:   make([]int, len(items)),
+	}
+
+	for i := range bs.pending {
+		bs.pending[i] = i
+	}
+
+	return bs
+}
+
+// merge incorporates the outcome of one attempt and computes the items
+// that are still pending.
+func (bs *batchState[I, R]) merge(results []R, errs []error) {
+	var retry []int
+	for j, i := range bs.pending {
+		if results != nil {
+			bs.results[i] = results[j]
+		}
+
+		bs.errs[i] = nil
+		if errs != nil {
+			bs.errs[i] = errs[j]
+		}
+
+		if bs.errs[i] != nil && bs.retryItem(bs.errs[i]) {
+			retry = append(retry, i)
+		}
+	}
+
+	bs.pending = retry
+}
+
+// batchError returns a BatchError if any item has failed, nil otherwise.
+func (bs *batchState[I, R]) batchError() error {
+	for _, err := range bs.errs {
+		if err != nil {
+			return &BatchError{
+				Errs:      slices.Clone(bs.errs),
+				retryItem: bs.retryItem,
+			}
+		}
+	}
+
+	return nil
+}
+
+// attempt is the Task that sends the pending items to the BatchTask.
+func (bs *batchState[I, R]) attempt(ctx context.Context) ([]R, error) {
+	subset := make([]I, len(bs.pending))
+	for j, i := range bs.pending {
+		subset[j] = bs.items[i]
+	}
+
+	results, errs, err := bs.task(ctx, subset)
+	switch {
+	case err != nil:
+		for _, i := range bs.pending {
+			bs.errs[i] = err
+		}
+
+		return bs.results, err
+
+	case (results != nil && len(results) != len(subset)) || (errs != nil && len(errs) != len(subset)):
+		return bs.results, SetRetryable(ErrBatchMismatch, false)
+	}
+
+	bs.merge(results, errs)
+	return bs.results, bs.batchError()
+}
+
+// RunBatch uses a Runner to process a batch of items, where each attempt only sends the
+// items that failed on the previous attempt.  Items that succeed, or that fail with an
+// error that should not be retried according to retryItem, are not sent again.  This is
+// useful for bulk APIs that report mixed results, such as an HTTP 207 Multi-Status.
+//
+// The retryItem predicate should agree with the Runner's ShouldRetry strategy.  For
+// example, a Runner created with WithClassifier(c) should be paired with c.ShouldRetry.
+// If retryItem is nil, DefaultTestErrorForRetry is used.
+//
+// The returned results are merged across all attempts and are in the same order as the
+// items.  If any item did not ultimately succeed, the returned error is a *BatchError.
+// Otherwise, the returned error is nil.
+//
+// The Runner will see a *BatchError for each attempt in which any item failed.  If the
+// Runner has a custom ShouldRetry predicate, it must take that into account.  Since each
+// attempt depends on the outcome of the previous one, the Runner must not use WithHedging.
+func RunBatch[I, R any](ctx context.Context, r Runner[[]R], items []I, task BatchTask[I, R], retryItem func(error) bool) ([]R, error) {
+	if len(items) == 0 {
+		return []R{}, nil
+	}
+
+	bs := newBatchState(items, task, retryItem)
+	_, err := r.Run(ctx, bs.attempt)
+	if err == nil || errors.Is(err, ErrBatchMismatch) {
+		return bs.results, err
+	}
+
+	// any items that never got an attempt take the error from the runner
+	for _, i := range bs.pending {
+		if bs.errs[i] == nil {
+			bs.errs[i] = err
+		}
+	}
+
+	return bs.results, bs.batchError()
+}
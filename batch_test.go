@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchSuite struct {
+	CommonSuite
+}
+
+func (suite *BatchSuite) newBatchRunner(o ...RunnerOption[[]string]) Runner[[]string] {
+	runner, err := NewRunner(
+		append(
+			[]RunnerOption[[]string]{
+				WithImmediateTimer[[]string](),
+				WithPolicyFactory[[]string](Config{
+					Interval:   time.Second,
+					MaxRetries: 5,
+				}),
+			},
+			o...,
+		)...,
+	)
+
+	suite.Require().NoError(err)
+	return runner
+}
+
+// newScriptedTask returns a BatchTask that fails each item a given number of times
+// with the given error before succeeding.  Each batch sent is recorded.
+func (suite *BatchSuite) newScriptedTask(failures map[int]int, err error, sent *[][]int) BatchTask[int, string] {
+	return func(ctx context.Context, items []int) (results []string, errs []error, _ error) {
+		suite.assertTestCtx(ctx)
+		*sent = append(*sent, items)
+		results = make([]string, len(items))
+		errs = make([]error, len(items))
+		for j, item := range items {
+			if failures[item] > 0 {
+				failures[item]--
+				results[j] = "failed"
+				errs[j] = err
+			} else {
+				results[j] = "ok"
+			}
+		}
+
+		return
+	}
+}
+
+func (suite *BatchSuite) TestRetriesFailedSubset() {
+	var (
+		testCtx, _ = suite.testCtx()
+		sent       [][]int
+		task       = suite.newScriptedTask(
+			map[int]int{1: 1, 3: 2},
+			errors.New("should retry this"),
+			&sent,
+		)
+	)
+
+	results, err := RunBatch(testCtx, suite.newBatchRunner(), []int{0, 1, 2, 3}, task, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"ok", "ok", "ok", "ok"}, results)
+	suite.Equal([][]int{{0, 1, 2, 3}, {1, 3}, {3}}, sent)
+}
+
+func (suite *BatchSuite) TestPermanentItemFailure() {
+	var (
+		testCtx, _ = suite.testCtx()
+		fatalErr   = SetRetryable(errors.New("fatal"), false)
+		retryErr   = errors.New("should retry this")
+		calls      int
+		task       = func(_ context.Context, items []int) ([]string, []error, error) {
+			calls++
+			errs := make([]error, len(items))
+			for j, item := range items {
+				switch {
+				case item == 0:
+					errs[j] = fatalErr
+				case item == 1 && calls == 1:
+					errs[j] = retryErr
+				}
+			}
+
+			return nil, errs, nil
+		}
+	)
+
+	results, err := RunBatch(testCtx, suite.newBatchRunner(), []int{0, 1, 2}, task, nil)
+	suite.Len(results, 3)
+	suite.Equal(2, calls)
+
+	var be *BatchError
+	suite.Require().ErrorAs(err, &be)
+	suite.Equal(1, be.Failed())
+	suite.ErrorIs(be.Errs[0], fatalErr)
+	suite.NoError(be.Errs[1])
+	suite.NoError(be.Errs[2])
+	suite.ErrorIs(err, fatalErr)
+	suite.False(be.ShouldRetry())
+	suite.Equal("1 of 3 batch items failed", be.Error())
+}
+
+func (suite *BatchSuite) TestWholeBatchFailure() {
+	var (
+		testCtx, _ = suite.testCtx()
+		networkErr = errors.New("network")
+		sent       [][]int
+		partial    = suite.newScriptedTask(map[int]int{2: 1}, errors.New("should retry this"), &sent)
+		calls      int
+		task       = func(ctx context.Context, items []int) ([]string, []error, error) {
+			calls++
+			if calls == 2 {
+				sent = append(sent, items)
+				return nil, nil, networkErr
+			}
+
+			return partial(ctx, items)
+		}
+	)
+
+	results, err := RunBatch(testCtx, suite.newBatchRunner(), []int{0, 1, 2}, task, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"ok", "ok", "ok"}, results)
+	suite.Equal([][]int{{0, 1, 2}, {2}, {2}}, sent)
+}
+
+func (suite *BatchSuite) TestRetriesExhausted() {
+	var (
+		testCtx, _ = suite.testCtx()
+		retryErr   = errors.New("should retry this")
+		sent       [][]int
+		task       = suite.newScriptedTask(map[int]int{1: 100}, retryErr, &sent)
+	)
+
+	results, err := RunBatch(testCtx, suite.newBatchRunner(), []int{0, 1}, task, nil)
+	suite.Equal([]string{"ok", "failed"}, results)
+	suite.Len(sent, 6)
+
+	var be *BatchError
+	suite.Require().ErrorAs(err, &be)
+	suite.NoError(be.Errs[0])
+	suite.ErrorIs(be.Errs[1], retryErr)
+	suite.True(be.ShouldRetry())
+}
+
+func (suite *BatchSuite) TestRetryItem() {
+	var (
+		testCtx, _ = suite.testCtx()
+		sent       [][]int
+		calls      int
+		task       = func(_ context.Context, items []int) ([]string, []error, error) {
+			calls++
+			sent = append(sent, items)
+			errs := make([]error, len(items))
+			for j, item := range items {
+				switch {
+				case item == 0:
+					errs[j] = context.Canceled
+				case item == 1 && calls == 1:
+					errs[j] = io.ErrUnexpectedEOF
+				}
+			}
+
+			return nil, errs, nil
+		}
+	)
+
+	_, err := RunBatch(
+		testCtx,
+		suite.newBatchRunner(WithClassifier[[]string](nil)),
+		[]int{0, 1, 2},
+		task,
+		DefaultClassifier.ShouldRetry,
+	)
+
+	suite.Equal([][]int{{0, 1, 2}, {1}}, sent, "items the Classifier won't retry must not be resent")
+
+	var be *BatchError
+	suite.Require().ErrorAs(err, &be)
+	suite.Equal(1, be.Failed())
+	suite.ErrorIs(be.Errs[0], context.Canceled)
+	suite.False(be.ShouldRetry())
+}
+
+func (suite *BatchSuite) TestMismatch() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      int
+		task       = func(context.Context, []int) ([]string, []error, error) {
+			calls++
+			return []string{"too short"}, nil, nil
+		}
+	)
+
+	_, err := RunBatch(testCtx, suite.newBatchRunner(), []int{0, 1}, task, nil)
+	suite.ErrorIs(err, ErrBatchMismatch)
+	suite.Equal(1, calls)
+}
+
+func (suite *BatchSuite) TestCanceled() {
+	var (
+		testCtx, testCancel = suite.testCtx()
+		task                = func(context.Context, []int) ([]string, []error, error) {
+			suite.Fail("the task should not have been called")
+			return nil, nil, nil
+		}
+	)
+
+	testCancel()
+	_, err := RunBatch(testCtx, suite.newBatchRunner(), []int{0, 1}, task, nil)
+
+	var be *BatchError
+	suite.Require().ErrorAs(err, &be)
+	suite.Equal(2, be.Failed())
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *BatchSuite) TestEmpty() {
+	testCtx, _ := suite.testCtx()
+	results, err := RunBatch(
+		testCtx,
+		suite.newBatchRunner(),
+		nil,
+		func(context.Context, []int) ([]string, []error, error) {
+			suite.Fail("the task should not have been called")
+			return nil, nil, nil
+		},
+		nil,
+	)
+
+	suite.Empty(results)
+	suite.NoError(err)
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(BatchSuite))
+}
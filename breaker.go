@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Runner.Run when a circuit breaker refuses to allow
+// an attempt.  See WithCircuitBreaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+const (
+	// DefaultBreakerOpenTimeout is the cooldown for an open CircuitBreaker when
+	// BreakerConfig.OpenTimeout is unset.
+	DefaultBreakerOpenTimeout = 30 * time.Second
+
+	// DefaultBreakerWindowSize is the number of recent outcomes used to compute a
+	// failure rate when BreakerConfig.WindowSize is unset.
+	DefaultBreakerWindowSize = 100
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed is the normal state, where all attempts are allowed.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen is the state where all attempts are refused with ErrCircuitOpen.
+	BreakerOpen
+
+	// BreakerHalfOpen is the state where a limited number of probe attempts are allowed
+	// in order to determine if the breaker should close again.
+	BreakerHalfOpen
+)
+
+// String returns a human-readable label for this state.
+func (bs BreakerState) String() string {
+	switch bs {
+	case BreakerClosed:
+		return "closed"
+
+	case BreakerOpen:
+		return "open"
+
+	case BreakerHalfOpen:
+		return "half-open"
+
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig describes when a CircuitBreaker trips and how it recovers.  This type
+// is friendly to being unmarshaled from external sources.
+//
+// A breaker trips if either of the ConsecutiveFailures or FailureRate thresholds is met.
+// If neither threshold is set, the breaker never trips.
+type BreakerConfig struct {
+	// ConsecutiveFailures is the number of failures in a row that will trip the breaker.
+	// If this field is nonpositive, consecutive failures are not considered.
+	ConsecutiveFailures int `json:"consecutiveFailures" yaml:"consecutiveFailures"`
+
+	// FailureRate is the fraction, in (0.0, 1.0], of the recent outcomes that must be
+	// failures in order to trip the breaker.  If this field is nonpositive, the failure
+	// rate is not considered.
+	FailureRate float64 `json:"failureRate" yaml:"failureRate"`
+
+	// WindowSize is the number of most recent outcomes used to compute the failure rate.
+	// The failure rate is not considered until this many outcomes have been recorded.
+	// If this field is nonpositive, DefaultBreakerWindowSize is used.
+	WindowSize int `json:"windowSize" yaml:"windowSize"`
+
+	// OpenTimeout is the cooldown period that the breaker stays open before allowing
+	// probes.  If this field is nonpositive, DefaultBreakerOpenTimeout is used.
+	OpenTimeout time.Duration `json:"openTimeout" yaml:"openTimeout"`
+
+	// HalfOpenProbes is the number of probe attempts allowed while the breaker is half-open.
+	// All the probes must succeed for the breaker to close, and any failed probe opens the
+	// breaker again.  If this field is nonpositive, a single probe is allowed.
+	HalfOpenProbes int `json:"halfOpenProbes" yaml:"halfOpenProbes"`
+}
+
+// OnStateChange is a callback for CircuitBreaker state transitions.  This function
+// must not block, but it may safely call methods on the CircuitBreaker.
+type OnStateChange func(from, to BreakerState)
+
+// CircuitBreaker tracks the outcomes of attempts against a dependency and refuses
+// further attempts when that dependency appears to be unhealthy.  A CircuitBreaker
+// is safe for concurrent use and may be shared across Runners.
+type CircuitBreaker struct {
+	lock           sync.Mutex
+	cfg            BreakerConfig
+	now            func() time.Time
+	onStateChanges []OnStateChange
+
+	state      BreakerState
+	generation uint64
+	openedAt   time.Time
+
+	// closed state
+	consecutive int
+	window      []bool // true indicates a failure
+	windowNext  int
+	windowFull  bool
+	failures    int
+
+	// half-open state
+	probes    int
+	successes int
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker.  Any OnStateChange callbacks are
+// invoked, in order, each time the breaker changes state.
+func NewCircuitBreaker(cfg BreakerConfig, fns ...OnStateChange) *CircuitBreaker {
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = DefaultBreakerWindowSize
+	}
+
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+
+	return &CircuitBreaker{
+		cfg:            cfg,
+		now:            time.Now,
+		onStateChanges: append([]OnStateChange(nil), fns...),
+		window:         make([]bool, cfg.WindowSize),
+	}
+}
+
+// State returns the current state of this breaker.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.lock.Lock()
+	t := cb.checkCooldown()
+	state := cb.state
+	cb.lock.Unlock()
+
+	cb.dispatch(t)
+	return state
+}
+
+// breakerTransition records a state change that must be dispatched after the lock is released.
+type breakerTransition struct {
+	from, to BreakerState
+}
+
+// dispatch invokes the OnStateChange callbacks for a transition, if any.
+func (cb *CircuitBreaker) dispatch(t *breakerTransition) {
+	if t == nil {
+		return
+	}
+
+	for _, f := range cb.onStateChanges {
+		f(t.from, t.to)
+	}
+}
+
+// setState changes state and resets the counters for the new state.  This method must
+// be invoked under the lock.
+func (cb *CircuitBreaker) setState(to BreakerState) *breakerTransition {
+	t := &breakerTransition{from: cb.state, to: to}
+	cb.state = to
+	cb.generation++
+	cb.consecutive = 0
+	cb.windowNext = 0
+	cb.windowFull = false
+	cb.failures = 0
+	cb.probes = 0
+	cb.successes = 0
+	clear(cb.window)
+
+	if to == BreakerOpen {
+		cb.openedAt = cb.now()
+	}
+
+	return t
+}
+
+// checkCooldown moves an open breaker to half-open once the cooldown has elapsed.
+// This method must be invoked under the lock.
+func (cb *CircuitBreaker) checkCooldown() (t *breakerTransition) {
+	if cb.state == BreakerOpen && !cb.now().Before(cb.openedAt.Add(cb.cfg.OpenTimeout)) {
+		t = cb.setState(BreakerHalfOpen)
+	}
+
+	return
+}
+
+// allow determines if an attempt may proceed.  The returned generation must be passed
+// to record along with the outcome of the attempt.
+func (cb *CircuitBreaker) allow() (generation uint64, err error) {
+	cb.lock.Lock()
+	t := cb.checkCooldown()
+	switch {
+	case cb.state == BreakerOpen:
+		err = ErrCircuitOpen
+
+	case cb.state == BreakerHalfOpen && cb.probes >= cb.cfg.HalfOpenProbes:
+		err = ErrCircuitOpen
+
+	case cb.state == BreakerHalfOpen:
+		cb.probes++
+	}
+
+	generation = cb.generation
+	cb.lock.Unlock()
+
+	cb.dispatch(t)
+	return
+}
+
+// release gives back an attempt that was allowed but never executed.
+func (cb *CircuitBreaker) release(generation uint64) {
+	defer cb.lock.Unlock()
+	cb.lock.Lock()
+	if generation == cb.generation && cb.state == BreakerHalfOpen && cb.probes > 0 {
+		cb.probes--
+	}
+}
+
+// recordClosed updates the closed state counters and determines if the breaker should trip.
+// This method must be invoked under the lock.
+func (cb *CircuitBreaker) recordClosed(failed bool) bool {
+	if failed {
+		cb.consecutive++
+	} else {
+		cb.consecutive = 0
+	}
+
+	if cb.window[cb.windowNext] {
+		cb.failures--
+	}
+
+	cb.window[cb.windowNext] = failed
+	if failed {
+		cb.failures++
+	}
+
+	cb.windowNext++
+	if cb.windowNext >= len(cb.window) {
+		cb.windowNext = 0
+		cb.windowFull = true
+	}
+
+	switch {
+	case cb.cfg.ConsecutiveFailures > 0 && cb.consecutive >= cb.cfg.ConsecutiveFailures:
+		return true
+
+	case cb.cfg.FailureRate > 0.0 && cb.windowFull:
+		return float64(cb.failures)/float64(len(cb.window)) >= cb.cfg.FailureRate
+
+	default:
+		return false
+	}
+}
+
+// record updates this breaker with the outcome of an attempt.  Outcomes from a
+// previous generation, i.e. before the most recent state change, are ignored.
+func (cb *CircuitBreaker) record(generation uint64, failed bool) {
+	var t *breakerTransition
+	cb.lock.Lock()
+	if generation == cb.generation {
+		switch cb.state {
+		case BreakerClosed:
+			if cb.recordClosed(failed) {
+				t = cb.setState(BreakerOpen)
+			}
+
+		case BreakerHalfOpen:
+			if failed {
+				t = cb.setState(BreakerOpen)
+			} else if cb.successes++; cb.successes >= cb.cfg.HalfOpenProbes {
+				t = cb.setState(BreakerClosed)
+			}
+		}
+	}
+
+	cb.lock.Unlock()
+	cb.dispatch(t)
+}
+
+// WithCircuitBreaker associates a CircuitBreaker with the created task runner.  Each
+// attempt, including the first, must be allowed by the breaker.  If the first attempt
+// is refused, Run returns ErrCircuitOpen immediately without creating a retry policy.
+// If a retry is refused, Run stops and returns an error that wraps both ErrCircuitOpen and
+// the error from the last attempt.
+//
+// Each attempt is recorded as a failure if the runner's ShouldRetry strategy indicates
+// it should be retried, i.e. if it appears to be a transient problem with the dependency.
+// Any other attempt, including one that fails with a permanent error, is recorded as a success.
+// An attempt that fails because the context passed to Run was canceled is not recorded at
+// all.  An attempt that exceeds its own deadline is recorded as a failure, if retryable.
+//
+// The same CircuitBreaker may be shared by any number of Runners, regardless of their
+// result types.
+func WithCircuitBreaker[V any](cb *CircuitBreaker) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.breaker = cb
+		return nil
+	})
+}
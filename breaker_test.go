@@ -0,0 +1,379 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BreakerSuite struct {
+	CommonSuite
+
+	now         time.Time
+	transitions [][2]BreakerState
+}
+
+func (suite *BreakerSuite) SetupTest() {
+	suite.now = time.Now()
+	suite.transitions = nil
+}
+
+func (suite *BreakerSuite) onStateChange(from, to BreakerState) {
+	suite.transitions = append(suite.transitions, [2]BreakerState{from, to})
+}
+
+// newBreaker creates a CircuitBreaker whose time source is controlled by this suite.
+func (suite *BreakerSuite) newBreaker(cfg BreakerConfig) *CircuitBreaker {
+	cb := NewCircuitBreaker(cfg, suite.onStateChange)
+	suite.Require().NotNil(cb)
+	cb.now = func() time.Time { return suite.now }
+	return cb
+}
+
+// recordN records the given outcome n times, each through a separate allow.
+func (suite *BreakerSuite) recordN(cb *CircuitBreaker, n int, failed bool) {
+	for i := 0; i < n; i++ {
+		generation, err := cb.allow()
+		suite.Require().NoError(err)
+		cb.record(generation, failed)
+	}
+}
+
+func (suite *BreakerSuite) TestString() {
+	suite.Equal("closed", BreakerClosed.String())
+	suite.Equal("open", BreakerOpen.String())
+	suite.Equal("half-open", BreakerHalfOpen.String())
+	suite.Equal("unknown", BreakerState(-1).String())
+}
+
+func (suite *BreakerSuite) TestDefaults() {
+	cb := NewCircuitBreaker(BreakerConfig{})
+	suite.Require().NotNil(cb)
+	suite.Equal(BreakerClosed, cb.State())
+	suite.Equal(DefaultBreakerOpenTimeout, cb.cfg.OpenTimeout)
+	suite.Equal(DefaultBreakerWindowSize, cb.cfg.WindowSize)
+	suite.Equal(1, cb.cfg.HalfOpenProbes)
+
+	// with no thresholds, the breaker never trips
+	suite.recordN(cb, 2*DefaultBreakerWindowSize, true)
+	suite.Equal(BreakerClosed, cb.State())
+}
+
+func (suite *BreakerSuite) TestConsecutiveFailures() {
+	cb := suite.newBreaker(BreakerConfig{
+		ConsecutiveFailures: 3,
+		OpenTimeout:         time.Minute,
+	})
+
+	suite.recordN(cb, 2, true)
+	suite.recordN(cb, 1, false)
+	suite.recordN(cb, 2, true)
+	suite.Equal(BreakerClosed, cb.State())
+
+	suite.recordN(cb, 1, true)
+	suite.Equal(BreakerOpen, cb.State())
+
+	_, err := cb.allow()
+	suite.ErrorIs(err, ErrCircuitOpen)
+	suite.Equal([][2]BreakerState{{BreakerClosed, BreakerOpen}}, suite.transitions)
+}
+
+func (suite *BreakerSuite) TestFailureRate() {
+	cb := suite.newBreaker(BreakerConfig{
+		FailureRate: 0.5,
+		WindowSize:  4,
+	})
+
+	// the window isn't full yet
+	suite.recordN(cb, 3, true)
+	suite.Equal(BreakerClosed, cb.State())
+
+	// 3 of 4 failed
+	suite.recordN(cb, 1, false)
+	suite.Equal(BreakerOpen, cb.State())
+}
+
+func (suite *BreakerSuite) TestFailureRateWindowRolls() {
+	cb := suite.newBreaker(BreakerConfig{
+		FailureRate: 0.75,
+		WindowSize:  4,
+	})
+
+	suite.recordN(cb, 2, true)
+	suite.recordN(cb, 4, false)
+	suite.recordN(cb, 2, true)
+	suite.Equal(BreakerClosed, cb.State(), "only 2 of the last 4 failed")
+
+	suite.recordN(cb, 1, true)
+	suite.Equal(BreakerOpen, cb.State(), "3 of the last 4 failed")
+}
+
+func (suite *BreakerSuite) TestHalfOpen() {
+	cb := suite.newBreaker(BreakerConfig{
+		ConsecutiveFailures: 1,
+		OpenTimeout:         time.Minute,
+		HalfOpenProbes:      2,
+	})
+
+	suite.recordN(cb, 1, true)
+	suite.Equal(BreakerOpen, cb.State())
+
+	suite.now = suite.now.Add(time.Minute)
+	suite.Equal(BreakerHalfOpen, cb.State())
+
+	first, err := cb.allow()
+	suite.Require().NoError(err)
+	second, err := cb.allow()
+	suite.Require().NoError(err)
+	_, err = cb.allow()
+	suite.ErrorIs(err, ErrCircuitOpen, "only 2 probes are allowed")
+
+	cb.record(first, false)
+	suite.Equal(BreakerHalfOpen, cb.State())
+	cb.record(second, false)
+	suite.Equal(BreakerClosed, cb.State())
+
+	suite.Equal(
+		[][2]BreakerState{
+			{BreakerClosed, BreakerOpen},
+			{BreakerOpen, BreakerHalfOpen},
+			{BreakerHalfOpen, BreakerClosed},
+		},
+		suite.transitions,
+	)
+}
+
+func (suite *BreakerSuite) TestHalfOpenProbeFails() {
+	cb := suite.newBreaker(BreakerConfig{
+		ConsecutiveFailures: 1,
+		OpenTimeout:         time.Minute,
+	})
+
+	suite.recordN(cb, 1, true)
+	suite.now = suite.now.Add(time.Minute)
+	suite.recordN(cb, 1, true)
+	suite.Equal(BreakerOpen, cb.State())
+
+	// the cooldown starts over
+	suite.now = suite.now.Add(time.Minute - time.Second)
+	suite.Equal(BreakerOpen, cb.State())
+}
+
+func (suite *BreakerSuite) TestStaleOutcomesIgnored() {
+	cb := suite.newBreaker(BreakerConfig{
+		ConsecutiveFailures: 1,
+		OpenTimeout:         time.Minute,
+	})
+
+	stale, err := cb.allow()
+	suite.Require().NoError(err)
+	suite.recordN(cb, 1, true)
+	suite.now = suite.now.Add(time.Minute)
+	suite.Equal(BreakerHalfOpen, cb.State())
+
+	cb.record(stale, false)
+	suite.Equal(BreakerHalfOpen, cb.State())
+}
+
+func (suite *BreakerSuite) TestRelease() {
+	cb := suite.newBreaker(BreakerConfig{
+		ConsecutiveFailures: 1,
+		OpenTimeout:         time.Minute,
+	})
+
+	suite.recordN(cb, 1, true)
+	suite.now = suite.now.Add(time.Minute)
+
+	generation, err := cb.allow()
+	suite.Require().NoError(err)
+	_, err = cb.allow()
+	suite.Require().ErrorIs(err, ErrCircuitOpen)
+
+	cb.release(generation)
+	_, err = cb.allow()
+	suite.NoError(err)
+}
+
+func (suite *BreakerSuite) TestRunnerOpen() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		cb         = suite.newBreaker(BreakerConfig{
+			ConsecutiveFailures: 1,
+		})
+
+		runner = suite.newRunner(
+			WithCircuitBreaker[int](cb),
+			WithPolicyFactory[int](PolicyFactoryFunc(func(context.Context) Policy {
+				suite.Fail("no policy should be created when the breaker is open")
+				return nil
+			})),
+		)
+	)
+
+	suite.recordN(cb, 1, true)
+	result, err := runner.Run(testCtx, task.Do)
+	suite.Zero(result)
+	suite.ErrorIs(err, ErrCircuitOpen)
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *BreakerSuite) TestRunnerTripsDuringRetries() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		retryErr   = errors.New("should retry this")
+		cb         = suite.newBreaker(BreakerConfig{
+			ConsecutiveFailures: 2,
+		})
+
+		runner = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithCircuitBreaker[int](cb),
+			WithPolicyFactory[int](Config{
+				Interval:   time.Second,
+				MaxRetries: 5,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, retryErr).Times(2)
+	_, err := runner.Run(testCtx, task.Do)
+	suite.ErrorIs(err, ErrCircuitOpen)
+	suite.ErrorIs(err, retryErr, "the error from the last attempt must be preserved")
+	suite.Equal(BreakerOpen, cb.State())
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *BreakerSuite) TestRunnerPermanentErrorIsSuccess() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		fatalErr   = SetRetryable(errors.New("fatal"), false)
+		cb         = suite.newBreaker(BreakerConfig{
+			ConsecutiveFailures: 1,
+		})
+
+		runner = suite.newRunner(
+			WithCircuitBreaker[int](cb),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, fatalErr).Times(3)
+	for i := 0; i < 3; i++ {
+		_, err := runner.Run(testCtx, task.Do)
+		suite.ErrorIs(err, fatalErr)
+	}
+
+	suite.Equal(BreakerClosed, cb.State())
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *BreakerSuite) TestRunnerCanceledProbeReleased() {
+	var (
+		testCtx, testCancel = suite.testCtx()
+		task                = new(mockTask[int])
+		cb                  = suite.newBreaker(BreakerConfig{
+			ConsecutiveFailures: 1,
+			OpenTimeout:         time.Minute,
+		})
+
+		runner = suite.newRunner(
+			WithCircuitBreaker[int](cb),
+		)
+	)
+
+	suite.recordN(cb, 1, true)
+	suite.now = suite.now.Add(time.Minute)
+
+	testCancel()
+	_, err := runner.Run(testCtx, task.Do)
+	suite.ErrorIs(err, context.Canceled)
+
+	// the probe must still be available
+	_, err = cb.allow()
+	suite.NoError(err)
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *BreakerSuite) TestRunnerCanceledAttemptsNotRecorded() {
+	var (
+		cb = suite.newBreaker(BreakerConfig{
+			ConsecutiveFailures: 3,
+		})
+
+		runner = suite.newRunner(
+			WithCircuitBreaker[int](cb),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	for i := 0; i < 3; i++ {
+		testCtx, testCancel := suite.testCtx()
+		_, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+			// the caller gives up while the attempt is in progress
+			testCancel()
+			return -1, ctx.Err()
+		})
+
+		suite.ErrorIs(err, context.Canceled)
+	}
+
+	suite.Equal(BreakerClosed, cb.State())
+	suite.Empty(suite.transitions)
+
+	testCtx, _ := suite.testCtx()
+	result, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return 123, nil
+	})
+
+	suite.NoError(err)
+	suite.Equal(123, result)
+}
+
+func (suite *BreakerSuite) TestRunnerAttemptTimeoutsTrip() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      int
+		cb         = suite.newBreaker(BreakerConfig{
+			ConsecutiveFailures: 2,
+		})
+
+		runner = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithClassifier[int](nil),
+			WithCircuitBreaker[int](cb),
+			WithPolicyFactory[int](Config{
+				Interval:   time.Second,
+				MaxRetries: 5,
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		calls++
+
+		// the dependency hangs past the deadline for this attempt
+		attemptCtx, cancel := context.WithTimeout(ctx, time.Nanosecond)
+		defer cancel()
+		<-attemptCtx.Done()
+		return -1, attemptCtx.Err()
+	})
+
+	suite.ErrorIs(err, ErrCircuitOpen)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Equal(2, calls)
+	suite.Equal(BreakerOpen, cb.State())
+}
+
+func TestBreaker(t *testing.T) {
+	suite.Run(t, new(BreakerSuite))
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import "sync"
+
+const (
+	// DefaultBudgetMaxTokens is the capacity of a Budget when BudgetConfig.MaxTokens is unset.
+	DefaultBudgetMaxTokens float64 = 100.0
+
+	// DefaultBudgetRetryCost is the number of tokens each retry spends when
+	// BudgetConfig.RetryCost is unset.
+	DefaultBudgetRetryCost float64 = 1.0
+
+	// DefaultBudgetSuccessDeposit is the number of tokens each success deposits when
+	// BudgetConfig.SuccessDeposit is unset.  Along with DefaultBudgetRetryCost, this
+	// allows retries to make up roughly 10% of the traffic once a Budget is drained.
+	DefaultBudgetSuccessDeposit float64 = 0.1
+)
+
+// BudgetConfig describes a token bucket that limits retries.  This type is friendly
+// to being unmarshaled from external sources.
+type BudgetConfig struct {
+	// MaxTokens is the capacity of the budget.  A new Budget starts out full.
+	// If this field is nonpositive, DefaultBudgetMaxTokens is used.
+	MaxTokens float64 `json:"maxTokens" yaml:"maxTokens"`
+
+	// RetryCost is the number of tokens spent by each retry.  If this field
+	// is nonpositive, DefaultBudgetRetryCost is used.
+	RetryCost float64 `json:"retryCost" yaml:"retryCost"`
+
+	// SuccessDeposit is the number of tokens deposited by each successful attempt.
+	// If this field is nonpositive, DefaultBudgetSuccessDeposit is used.
+	SuccessDeposit float64 `json:"successDeposit" yaml:"successDeposit"`
+}
+
+// Budget is a token bucket that throttles retries, in the style of gRPC retry throttling.
+// Each successful attempt deposits tokens, and each retry spends them.  When a Budget
+// does not have enough tokens for a retry, that retry is suppressed and the task fails
+// with the error from its most recent attempt.  Initial attempts are never suppressed.
+//
+// A Budget is safe for concurrent use, and a single Budget is typically shared across
+// many Runners that talk to the same dependency.  This prevents an outage in that
+// dependency from being amplified by each Runner's retries.
+type Budget struct {
+	lock           sync.Mutex
+	tokens         float64
+	maxTokens      float64
+	retryCost      float64
+	successDeposit float64
+}
+
+// NewBudget creates a full Budget from the given configuration.
+func NewBudget(cfg BudgetConfig) *Budget {
+	b := &Budget{
+		maxTokens:      cfg.MaxTokens,
+		retryCost:      cfg.RetryCost,
+		successDeposit: cfg.SuccessDeposit,
+	}
+
+	if b.maxTokens <= 0.0 {
+		b.maxTokens = DefaultBudgetMaxTokens
+	}
+
+	if b.retryCost <= 0.0 {
+		b.retryCost = DefaultBudgetRetryCost
+	}
+
+	if b.successDeposit <= 0.0 {
+		b.successDeposit = DefaultBudgetSuccessDeposit
+	}
+
+	b.tokens = b.maxTokens
+	return b
+}
+
+// Tokens returns the number of tokens currently available.
+func (b *Budget) Tokens() float64 {
+	defer b.lock.Unlock()
+	b.lock.Lock()
+	return b.tokens
+}
+
+// deposit records a successful attempt.
+func (b *Budget) deposit() {
+	defer b.lock.Unlock()
+	b.lock.Lock()
+
+	b.tokens += b.successDeposit
+	if b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+}
+
+// withdraw attempts to spend the tokens for a retry.  If there are not enough
+// tokens, this method returns false and the retry should not happen.
+func (b *Budget) withdraw() bool {
+	defer b.lock.Unlock()
+	b.lock.Lock()
+
+	if b.tokens < b.retryCost {
+		return false
+	}
+
+	b.tokens -= b.retryCost
+	return true
+}
+
+// WithBudget associates a Budget with the created task runner.  Before each retry,
+// the runner withdraws tokens from the Budget.  If not enough tokens are available,
+// no further retries are attempted.  Each successful attempt deposits tokens.
+//
+// The same Budget may be shared by any number of Runners, regardless of their
+// result types.  Hedged attempts are not governed by a Budget.
+func WithBudget[V any](b *Budget) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.budget = b
+		return nil
+	})
+}
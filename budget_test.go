@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetSuite struct {
+	CommonSuite
+}
+
+func (suite *BudgetSuite) TestDefaults() {
+	b := NewBudget(BudgetConfig{})
+	suite.Require().NotNil(b)
+	suite.Equal(DefaultBudgetMaxTokens, b.Tokens())
+	suite.Equal(DefaultBudgetRetryCost, b.retryCost)
+	suite.Equal(DefaultBudgetSuccessDeposit, b.successDeposit)
+}
+
+func (suite *BudgetSuite) TestDepositAndWithdraw() {
+	b := NewBudget(BudgetConfig{
+		MaxTokens:      3.0,
+		RetryCost:      2.0,
+		SuccessDeposit: 0.5,
+	})
+
+	suite.Require().NotNil(b)
+	suite.Equal(3.0, b.Tokens())
+
+	b.deposit()
+	suite.Equal(3.0, b.Tokens(), "deposits must not exceed the maximum")
+
+	suite.True(b.withdraw())
+	suite.Equal(1.0, b.Tokens())
+	suite.False(b.withdraw())
+	suite.Equal(1.0, b.Tokens())
+
+	b.deposit()
+	b.deposit()
+	suite.True(b.withdraw())
+	suite.Zero(b.Tokens())
+}
+
+func (suite *BudgetSuite) TestSuppressesRetries() {
+	var (
+		testCtx, _ = suite.testCtx()
+		retryErr   = errors.New("should retry this")
+		budget     = NewBudget(BudgetConfig{
+			MaxTokens: 2.0,
+		})
+
+		intRunner = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithBudget[int](budget),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+
+		stringRunner, runnerErr = NewRunner(
+			WithImmediateTimer[string](),
+			WithBudget[string](budget),
+			WithPolicyFactory[string](Config{
+				Interval: time.Second,
+			}),
+		)
+
+		intCalls    int
+		stringCalls int
+	)
+
+	suite.Require().NoError(runnerErr)
+
+	// the policy allows unlimited retries, so only the budget halts things
+	_, err := intRunner.Run(testCtx, func(context.Context) (int, error) {
+		intCalls++
+		return -1, retryErr
+	})
+
+	suite.ErrorIs(err, retryErr)
+	suite.Equal(3, intCalls, "the initial attempt plus the 2 retries allowed by the budget")
+	suite.Zero(budget.Tokens())
+
+	// a drained budget still allows initial attempts, and successes refill it
+	result, err := stringRunner.Run(testCtx, func(context.Context) (string, error) {
+		stringCalls++
+		return "success", nil
+	})
+
+	suite.NoError(err)
+	suite.Equal("success", result)
+	suite.Equal(1, stringCalls)
+	suite.Equal(DefaultBudgetSuccessDeposit, budget.Tokens())
+}
+
+func (suite *BudgetSuite) TestFailuresDoNotDeposit() {
+	var (
+		testCtx, _ = suite.testCtx()
+		fatalErr   = SetRetryable(errors.New("fatal"), false)
+		budget     = NewBudget(BudgetConfig{
+			MaxTokens: 2.0,
+		})
+
+		runner = suite.newRunner(
+			WithBudget[int](budget),
+		)
+	)
+
+	suite.Require().True(budget.withdraw())
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return -1, fatalErr
+	})
+
+	suite.ErrorIs(err, fatalErr)
+	suite.Equal(1.0, budget.Tokens())
+}
+
+func TestBudget(t *testing.T) {
+	suite.Run(t, new(BudgetSuite))
+}
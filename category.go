@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+// Category describes what kind of failure an error represents.  Categories allow
+// retry predicates, metrics, and logs to reason about failures consistently.
+type Category int
+
+const (
+	// CategoryNone indicates that there was no error, i.e. a successful attempt.
+	CategoryNone Category = iota
+
+	// CategoryUnknown is used for errors that could not be categorized.  Such
+	// errors are retried.  See Classifier.SetFallback to change how a Classifier
+	// treats these errors.
+	CategoryUnknown
+
+	// CategoryTransient is a short-lived failure, such as a connection reset, that
+	// is likely to succeed if tried again.
+	CategoryTransient
+
+	// CategoryThrottled indicates that a dependency asked the caller to slow down.
+	CategoryThrottled
+
+	// CategoryTimeout indicates that an operation did not complete in time, such as
+	// a request that exceeded a deadline set for a single attempt.
+	CategoryTimeout
+
+	// CategoryUnavailable indicates that a dependency could not be reached at all,
+	// such as a refused connection.
+	CategoryUnavailable
+
+	// CategoryPermanent is a failure that will not succeed if tried again.
+	CategoryPermanent
+
+	// CategoryCanceled indicates that a context was canceled.  Retrying is pointless.
+	CategoryCanceled
+)
+
+// String returns a human-readable label for this category, suitable for logs and metrics.
+func (c Category) String() string {
+	switch c {
+	case CategoryNone:
+		return "none"
+
+	case CategoryUnknown:
+		return "unknown"
+
+	case CategoryTransient:
+		return "transient"
+
+	case CategoryThrottled:
+		return "throttled"
+
+	case CategoryTimeout:
+		return "timeout"
+
+	case CategoryUnavailable:
+		return "unavailable"
+
+	case CategoryPermanent:
+		return "permanent"
+
+	case CategoryCanceled:
+		return "canceled"
+
+	default:
+		return "invalid"
+	}
+}
+
+// Retryable indicates whether errors in this category should be retried.  CategoryUnknown,
+// CategoryTransient, CategoryThrottled, CategoryTimeout, and CategoryUnavailable are retryable.
+// All other categories are not.
+func (c Category) Retryable() bool {
+	switch c {
+	case CategoryUnknown, CategoryTransient, CategoryThrottled, CategoryTimeout, CategoryUnavailable:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// Categorizer is an interface that errors may implement to declare their Category.
+type Categorizer interface {
+	// Category returns the kind of failure this error represents.
+	Category() Category
+}
+
+type categoryWrapper struct {
+	error
+	category Category
+}
+
+func (cw categoryWrapper) Unwrap() error      { return cw.error }
+func (cw categoryWrapper) Category() Category { return cw.category }
+func (cw categoryWrapper) ShouldRetry() bool  { return cw.category.Retryable() }
+
+// SetCategory associates a Category with a given error.  The returned error implements
+// Categorizer, returning the given category, and provides an Unwrap method for the original
+// error.  The returned error also implements ShouldRetryable, using Category.Retryable.
+func SetCategory(err error, c Category) error {
+	return categoryWrapper{
+		error:    err,
+		category: c,
+	}
+}
+
+// declaredCategory walks an error's chain, in the same order as errors.As, looking for
+// the first error that declares its own category, either via Categorizer or ShouldRetryable.
+func declaredCategory(err error) (Category, bool) {
+	for err != nil {
+		switch e := err.(type) {
+		case Categorizer:
+			return e.Category(), true
+
+		case ShouldRetryable:
+			if e.ShouldRetry() {
+				return CategoryTransient, true
+			}
+
+			return CategoryPermanent, true
+		}
+
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				if c, ok := declaredCategory(e); ok {
+					return c, true
+				}
+			}
+
+			return CategoryUnknown, false
+
+		default:
+			return CategoryUnknown, false
+		}
+	}
+
+	return CategoryUnknown, false
+}
+
+// CategoryOf uses the DefaultClassifier to determine the Category of an error.
+// A nil error always has CategoryNone.
+func CategoryOf(err error) Category {
+	return DefaultClassifier.Categorize(err)
+}
+
+// RetryOnCategories returns a ShouldRetry predicate that retries only errors whose
+// Category, according to the DefaultClassifier, is one of the given categories.
+// Successful results are never retried.
+func RetryOnCategories[V any](categories ...Category) ShouldRetry[V] {
+	set := make(map[Category]bool, len(categories))
+	for _, c := range categories {
+		set[c] = true
+	}
+
+	return func(_ V, err error) bool {
+		return err != nil && set[CategoryOf(err)]
+	}
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CategorySuite struct {
+	CommonSuite
+}
+
+func (suite *CategorySuite) TestString() {
+	testCases := []struct {
+		category Category
+		expected string
+	}{
+		{CategoryNone, "none"},
+		{CategoryUnknown, "unknown"},
+		{CategoryTransient, "transient"},
+		{CategoryThrottled, "throttled"},
+		{CategoryTimeout, "timeout"},
+		{CategoryUnavailable, "unavailable"},
+		{CategoryPermanent, "permanent"},
+		{CategoryCanceled, "canceled"},
+		{Category(-1), "invalid"},
+		{Category(1000), "invalid"},
+	}
+
+	for _, testCase := range testCases {
+		suite.Equal(testCase.expected, testCase.category.String())
+	}
+}
+
+func (suite *CategorySuite) TestRetryable() {
+	for _, c := range []Category{CategoryUnknown, CategoryTransient, CategoryThrottled, CategoryTimeout, CategoryUnavailable} {
+		suite.True(c.Retryable(), c.String())
+	}
+
+	for _, c := range []Category{CategoryNone, CategoryPermanent, CategoryCanceled, Category(-1), Category(1000)} {
+		suite.False(c.Retryable(), c.String())
+	}
+}
+
+func (suite *CategorySuite) TestSetCategory() {
+	var (
+		expectedErr = errors.New("expected")
+		err         = SetCategory(expectedErr, CategoryThrottled)
+	)
+
+	suite.ErrorIs(err, expectedErr)
+
+	var c Categorizer
+	suite.Require().ErrorAs(err, &c)
+	suite.Equal(CategoryThrottled, c.Category())
+
+	suite.True(DefaultTestErrorForRetry(err))
+	suite.False(DefaultTestErrorForRetry(SetCategory(expectedErr, CategoryPermanent)))
+}
+
+func (suite *CategorySuite) TestOutermostDeclarationWins() {
+	var (
+		inner = SetCategory(io.EOF, CategoryPermanent)
+		outer = SetRetryable(fmt.Errorf("wrapped: %w", inner), true)
+	)
+
+	suite.Equal(CategoryTransient, CategoryOf(outer))
+	suite.Equal(CategoryThrottled, CategoryOf(SetCategory(outer, CategoryThrottled)))
+	suite.Equal(CategoryPermanent, CategoryOf(fmt.Errorf("wrapped: %w", inner)))
+}
+
+func (suite *CategorySuite) TestJoined() {
+	suite.Equal(
+		CategoryThrottled,
+		CategoryOf(errors.Join(errors.New("plain"), SetCategory(io.EOF, CategoryThrottled))),
+	)
+
+	suite.Equal(
+		CategoryTransient,
+		CategoryOf(errors.Join(errors.New("plain"), io.ErrUnexpectedEOF)),
+		"the standard rules apply when no error declares its category",
+	)
+}
+
+func (suite *CategorySuite) TestCategoryOf() {
+	suite.Equal(CategoryNone, CategoryOf(nil))
+	suite.Equal(CategoryCanceled, CategoryOf(context.Canceled))
+	suite.Equal(CategoryUnknown, CategoryOf(errors.New("unknown")))
+}
+
+func (suite *CategorySuite) TestRetryOnCategories() {
+	sr := RetryOnCategories[int](CategoryThrottled, CategoryTimeout)
+	suite.False(sr(0, nil))
+	suite.True(sr(0, SetCategory(errors.New("slow down"), CategoryThrottled)))
+	suite.True(sr(0, context.DeadlineExceeded))
+	suite.False(sr(0, context.Canceled))
+	suite.False(sr(0, io.EOF))
+}
+
+func (suite *CategorySuite) TestAttemptCategory() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		onAttempt  = new(mockOnAttempt[int])
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithOnAttempt(onAttempt.OnAttempt),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, io.ErrUnexpectedEOF).Once()
+	task.ExpectMatch(suite.assertTestCtx, 123, nil).Once()
+	onAttempt.ExpectMatch(func(a Attempt[int]) bool {
+		return a.Retries == 0 && a.Category == CategoryTransient
+	}).Once()
+	onAttempt.ExpectMatch(func(a Attempt[int]) bool {
+		return a.Retries == 1 && a.Category == CategoryNone
+	}).Once()
+
+	result, err := runner.Run(testCtx, task.Do)
+	suite.Equal(123, result)
+	suite.NoError(err)
+
+	task.AssertExpectations(suite.T())
+	onAttempt.AssertExpectations(suite.T())
+}
+
+func (suite *CategorySuite) TestAttemptCategoryWithClassifier() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		onAttempt  = new(mockOnAttempt[int])
+		runner     = suite.newRunner(
+			WithClassifier[int](NewClassifier(RuleForErrors(CategoryPermanent, io.EOF))),
+			WithOnAttempt(onAttempt.OnAttempt),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, io.EOF).Once()
+	onAttempt.ExpectMatch(func(a Attempt[int]) bool {
+		return a.Category == CategoryPermanent
+	}).Once()
+
+	_, err := runner.Run(testCtx, task.Do)
+	suite.ErrorIs(err, io.EOF)
+
+	task.AssertExpectations(suite.T())
+	onAttempt.AssertExpectations(suite.T())
+}
+
+func TestCategory(t *testing.T) {
+	suite.Run(t, new(CategorySuite))
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Config is an Injector that injects faults randomly, each kind with its own probability.
+// This type is friendly to being unmarshaled from external sources.  The zero value
+// never injects anything.
+//
+// Latency is decided independently of failures, so a call may be both delayed and failed.
+// If a call is chosen to fail with an error, it does not also receive a status code.
+type Config struct {
+	// LatencyRate is the probability, in [0.0, 1.0], that a call is delayed.
+	LatencyRate float64 `json:"latencyRate" yaml:"latencyRate"`
+
+	// Latency is the delay added to a call.  If MaxLatency is greater than this field,
+	// the delay is chosen randomly from [Latency, MaxLatency].
+	Latency time.Duration `json:"latency" yaml:"latency"`
+
+	// MaxLatency is the upper limit for a random delay.  If this field is not greater
+	// than Latency, it is ignored.
+	MaxLatency time.Duration `json:"maxLatency" yaml:"maxLatency"`
+
+	// ErrorRate is the probability, in [0.0, 1.0], that a call fails with Err.
+	ErrorRate float64 `json:"errorRate" yaml:"errorRate"`
+
+	// Err is the error injected into calls.  If unset, ErrInjected is used.
+	Err error `json:"-" yaml:"-"`
+
+	// StatusRate is the probability, in [0.0, 1.0], that an HTTP call receives a synthetic
+	// response with StatusCode.  This field is ignored for tasks.
+	StatusRate float64 `json:"statusRate" yaml:"statusRate"`
+
+	// StatusCode is the HTTP status code of injected responses.  If unset,
+	// http.StatusServiceUnavailable is used.
+	StatusCode int `json:"statusCode" yaml:"statusCode"`
+}
+
+// roll returns true with the given probability.
+func roll(p float64) bool {
+	return p > 0.0 && rand.Float64() < p //nolint:gosec
+}
+
+// latency computes the delay for a call that was chosen to be delayed.
+func (c Config) latency() time.Duration {
+	if c.MaxLatency > c.Latency {
+		return c.Latency + time.Duration(rand.Int63n(int64(c.MaxLatency-c.Latency)+1)) //nolint:gosec
+	}
+
+	return c.Latency
+}
+
+// Fault implements Injector and randomly decides the Fault for a call.
+func (c Config) Fault() (f Fault) {
+	if roll(c.LatencyRate) {
+		f.Latency = c.latency()
+	}
+
+	switch {
+	case roll(c.ErrorRate):
+		f.Err = c.Err
+		if f.Err == nil {
+			f.Err = ErrInjected
+		}
+
+	case roll(c.StatusRate):
+		f.StatusCode = c.StatusCode
+		if f.StatusCode == 0 {
+			f.StatusCode = http.StatusServiceUnavailable
+		}
+	}
+
+	return
+}
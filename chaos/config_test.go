@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigSuite) TestZero() {
+	for i := 0; i < 100; i++ {
+		suite.Equal(Fault{}, Config{}.Fault())
+	}
+}
+
+func (suite *ConfigSuite) TestAlways() {
+	testCases := []struct {
+		label    string
+		cfg      Config
+		expected Fault
+	}{
+		{
+			label:    "DefaultError",
+			cfg:      Config{ErrorRate: 1.0},
+			expected: Fault{Err: ErrInjected},
+		},
+		{
+			label:    "DefaultStatusCode",
+			cfg:      Config{StatusRate: 1.0},
+			expected: Fault{StatusCode: http.StatusServiceUnavailable},
+		},
+		{
+			label:    "StatusCode",
+			cfg:      Config{StatusRate: 1.0, StatusCode: http.StatusTooManyRequests},
+			expected: Fault{StatusCode: http.StatusTooManyRequests},
+		},
+		{
+			label:    "ErrorTakesPrecedence",
+			cfg:      Config{ErrorRate: 1.0, StatusRate: 1.0},
+			expected: Fault{Err: ErrInjected},
+		},
+		{
+			label:    "Latency",
+			cfg:      Config{LatencyRate: 1.0, Latency: time.Second, MaxLatency: time.Millisecond},
+			expected: Fault{Latency: time.Second},
+		},
+		{
+			label:    "LatencyAndError",
+			cfg:      Config{LatencyRate: 1.0, Latency: time.Second, ErrorRate: 1.0},
+			expected: Fault{Latency: time.Second, Err: ErrInjected},
+		},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.label, func() {
+			suite.Equal(testCase.expected, testCase.cfg.Fault())
+		})
+	}
+}
+
+func (suite *ConfigSuite) TestCustomError() {
+	expectedErr := errors.New("expected")
+	suite.Same(expectedErr, Config{ErrorRate: 1.0, Err: expectedErr}.Fault().Err)
+}
+
+func (suite *ConfigSuite) TestLatencyRange() {
+	cfg := Config{
+		LatencyRate: 1.0,
+		Latency:     time.Second,
+		MaxLatency:  2 * time.Second,
+	}
+
+	for i := 0; i < 100; i++ {
+		f := cfg.Fault()
+		suite.GreaterOrEqual(f.Latency, time.Second)
+		suite.LessOrEqual(f.Latency, 2*time.Second)
+		suite.NoError(f.Err)
+		suite.Zero(f.StatusCode)
+	}
+}
+
+func (suite *ConfigSuite) TestRates() {
+	var (
+		cfg      = Config{ErrorRate: 0.5}
+		failures int
+	)
+
+	for i := 0; i < 1000; i++ {
+		if cfg.Fault().Err != nil {
+			failures++
+		}
+	}
+
+	// very loose bounds, so that this test is not flaky
+	suite.Greater(failures, 300)
+	suite.Less(failures, 700)
+}
+
+func (suite *ConfigSuite) TestUnmarshal() {
+	var cfg Config
+	suite.Require().NoError(json.Unmarshal(
+		[]byte(`{"latencyRate": 0.1, "latency": 1000000000, "errorRate": 0.2, "statusRate": 0.3, "statusCode": 429}`),
+		&cfg,
+	))
+
+	suite.Equal(
+		Config{
+			LatencyRate: 0.1,
+			Latency:     time.Second,
+			ErrorRate:   0.2,
+			StatusRate:  0.3,
+			StatusCode:  http.StatusTooManyRequests,
+		},
+		cfg,
+	)
+}
+
+func TestConfig(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package chaos injects faults into tasks and HTTP clients.  It is intended for staging
+environments and integration tests, in order to verify that a retry configuration
+survives a flaky dependency.
+
+An Injector decides the Fault, if any, for each call.  Config injects faults randomly
+and can be unmarshaled from external sources, while Script injects a predetermined
+pattern of faults:
+
+	client, _ := retryhttp.NewClient(
+		retryhttp.WithHTTPClient(
+			chaos.WrapHTTPClient(
+				http.DefaultClient,
+				chaos.Config{
+					StatusRate:  0.25,
+					StatusCode:  http.StatusServiceUnavailable,
+					LatencyRate: 0.1,
+					Latency:     2 * time.Second,
+				},
+			),
+		),
+		retryhttp.WithRunner(runner),
+	)
+*/
+package chaos
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInjected is the error injected when a fault doesn't specify one.
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Fault describes what to inject into a single call.  The zero value injects nothing,
+// and the call proceeds normally.
+type Fault struct {
+	// Latency is the delay added before the call proceeds or fails.
+	Latency time.Duration
+
+	// Err is the error the call fails with.  If set, the wrapped task or client
+	// is not invoked.
+	Err error
+
+	// StatusCode is the HTTP status code of a synthetic response.  If set, and Err is
+	// not, the wrapped client is not invoked.  This field is ignored for tasks.
+	StatusCode int
+}
+
+// Injector is a strategy for deciding which Fault, if any, to inject into a call.
+// Implementations must be safe for concurrent use.
+type Injector interface {
+	// Fault returns the Fault to inject into the current call.
+	Fault() Fault
+}
+
+// InjectorFunc is a function type that implements Injector.
+type InjectorFunc func() Fault
+
+func (f InjectorFunc) Fault() Fault { return f() }
+
+type script struct {
+	lock   sync.Mutex
+	faults []Fault
+	next   int
+}
+
+// Script creates an Injector that returns the given faults in order, starting over
+// once they are exhausted.  Use a zero Fault to let a call proceed normally.  For
+// example, this fails every third call:
+//
+//	chaos.Script(chaos.Fault{}, chaos.Fault{}, chaos.Fault{Err: chaos.ErrInjected})
+//
+// If no faults are given, the returned Injector never injects anything.
+func Script(faults ...Fault) Injector {
+	return &script{
+		faults: append([]Fault(nil), faults...),
+	}
+}
+
+func (s *script) Fault() (f Fault) {
+	defer s.lock.Unlock()
+	s.lock.Lock()
+	if len(s.faults) > 0 {
+		f = s.faults[s.next]
+		s.next = (s.next + 1) % len(s.faults)
+	}
+
+	return
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FaultSuite struct {
+	suite.Suite
+}
+
+func (suite *FaultSuite) TestInjectorFunc() {
+	expected := Fault{Latency: time.Second}
+	suite.Equal(expected, InjectorFunc(func() Fault { return expected }).Fault())
+}
+
+func (suite *FaultSuite) TestScript() {
+	var (
+		failure = Fault{Err: ErrInjected}
+		slow    = Fault{Latency: time.Second}
+		i       = Script(Fault{}, failure, slow)
+	)
+
+	for round := 0; round < 2; round++ {
+		suite.Equal(Fault{}, i.Fault())
+		suite.Equal(failure, i.Fault())
+		suite.Equal(slow, i.Fault())
+	}
+}
+
+func (suite *FaultSuite) TestEmptyScript() {
+	i := Script()
+	suite.Equal(Fault{}, i.Fault())
+	suite.Equal(Fault{}, i.Fault())
+}
+
+func TestFault(t *testing.T) {
+	suite.Run(t, new(FaultSuite))
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/xmidt-org/retry/retryhttp"
+)
+
+type httpClient struct {
+	hc retryhttp.HTTPClient
+	i  Injector
+}
+
+// WrapHTTPClient decorates an HTTP client so that each request consults the Injector.
+// Any latency is added first, honoring the request's context.  Then the request either
+// fails with the injected error, receives a synthetic response with the injected status
+// code, or is passed to the given client.
+//
+// Just as with http.Client, any error returned by the decorated client is a *url.Error.
+// That includes the request's context error, if the context ends during injected latency.
+//
+// If hc is nil, http.DefaultClient is used.
+func WrapHTTPClient(hc retryhttp.HTTPClient, i Injector) retryhttp.HTTPClient {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	return &httpClient{
+		hc: hc,
+		i:  i,
+	}
+}
+
+// methodName returns the method in the same form that http.Client uses for url.Error.
+func methodName(method string) string {
+	if method == "" {
+		return "Get"
+	}
+
+	return method[:1] + strings.ToLower(method[1:])
+}
+
+// newURLError wraps an error for a request in the same way that http.Client does.
+func newURLError(request *http.Request, err error) *url.Error {
+	return &url.Error{
+		Op:  methodName(request.Method),
+		URL: request.URL.String(),
+		Err: err,
+	}
+}
+
+// newResponse creates a synthetic response with the given status code and an empty body.
+func newResponse(request *http.Request, statusCode int) *http.Response {
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    request,
+	}
+}
+
+func (c *httpClient) Do(request *http.Request) (*http.Response, error) {
+	f := c.i.Fault()
+	if err := delay(request.Context(), f.Latency); err != nil {
+		return nil, newURLError(request, err)
+	}
+
+	switch {
+	case f.Err != nil:
+		return nil, newURLError(request, f.Err)
+
+	case f.StatusCode > 0:
+		return newResponse(request, f.StatusCode), nil
+
+	default:
+		return c.hc.Do(request)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+	"github.com/xmidt-org/retry/retryhttp"
+)
+
+// temporaryError is an error that net/http would consider temporary.
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "temporary" }
+func (temporaryError) Temporary() bool { return true }
+
+type HTTPClientSuite struct {
+	suite.Suite
+
+	server *httptest.Server
+	calls  int
+}
+
+func (suite *HTTPClientSuite) SetupTest() {
+	suite.calls = 0
+	suite.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		suite.calls++
+		rw.WriteHeader(http.StatusOK)
+	}))
+}
+
+func (suite *HTTPClientSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *HTTPClientSuite) newRequest(ctx context.Context) *http.Request {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, suite.server.URL, nil)
+	suite.Require().NoError(err)
+	return request
+}
+
+func (suite *HTTPClientSuite) do(i Injector, ctx context.Context) (*http.Response, error) {
+	response, err := WrapHTTPClient(suite.server.Client(), i).Do(suite.newRequest(ctx))
+	if response != nil {
+		response.Body.Close()
+	}
+
+	return response, err
+}
+
+func (suite *HTTPClientSuite) TestNoFault() {
+	response, err := suite.do(Script(), context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(1, suite.calls)
+}
+
+func (suite *HTTPClientSuite) TestDefaultClient() {
+	response, err := WrapHTTPClient(nil, Script()).Do(suite.newRequest(context.Background()))
+	suite.Require().NoError(err)
+	response.Body.Close()
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(1, suite.calls)
+}
+
+func (suite *HTTPClientSuite) TestError() {
+	response, err := suite.do(Script(Fault{Err: ErrInjected, StatusCode: http.StatusBadGateway}), context.Background())
+	suite.Nil(response)
+	suite.ErrorIs(err, ErrInjected)
+	suite.Zero(suite.calls)
+
+	var urlErr *url.Error
+	suite.Require().ErrorAs(err, &urlErr)
+	suite.Equal("Get", urlErr.Op)
+	suite.Equal(suite.server.URL, urlErr.URL)
+}
+
+func (suite *HTTPClientSuite) TestStatusCode() {
+	request := suite.newRequest(context.Background())
+	response, err := WrapHTTPClient(suite.server.Client(), Script(Fault{StatusCode: http.StatusTooManyRequests})).Do(request)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(response)
+	suite.NoError(response.Body.Close())
+
+	suite.Equal(http.StatusTooManyRequests, response.StatusCode)
+	suite.Equal("429 Too Many Requests", response.Status)
+	suite.Same(request, response.Request)
+	suite.Zero(suite.calls)
+}
+
+func (suite *HTTPClientSuite) TestLatencyCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	response, err := suite.do(Script(Fault{Latency: time.Hour}), ctx)
+	suite.Nil(response)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Zero(suite.calls)
+
+	var urlErr *url.Error
+	suite.Require().ErrorAs(err, &urlErr, "context errors must be wrapped like those of http.Client")
+	suite.Equal("Get", urlErr.Op)
+	suite.Equal(suite.server.URL, urlErr.URL)
+}
+
+func (suite *HTTPClientSuite) TestSurvivesWithRetries() {
+	runner, err := retry.NewRunner(
+		retry.WithImmediateTimer[*http.Response](),
+		retry.WithPolicyFactory[*http.Response](retry.Config{
+			Interval:   time.Second,
+			MaxRetries: 3,
+		}),
+		retryhttp.WithShouldRetry(http.StatusServiceUnavailable),
+		retry.WithOnAttempt(retryhttp.CleanupResponse),
+	)
+
+	suite.Require().NoError(err)
+	client, err := retryhttp.NewClient(
+		retryhttp.WithHTTPClient(
+			WrapHTTPClient(
+				suite.server.Client(),
+				Script(Fault{StatusCode: http.StatusServiceUnavailable}, Fault{Err: temporaryError{}}, Fault{}),
+			),
+		),
+		retryhttp.WithRunner(runner),
+	)
+
+	suite.Require().NoError(err)
+	response, err := client.Do(suite.newRequest(context.Background()))
+	suite.Require().NoError(err)
+	response.Body.Close()
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(1, suite.calls)
+}
+
+func TestHTTPClient(t *testing.T) {
+	suite.Run(t, new(HTTPClientSuite))
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"context"
+	"time"
+
+	"github.com/xmidt-org/retry"
+)
+
+// delay waits out the given latency.  If ctx is canceled first, ctx.Err() is returned.
+func delay(ctx context.Context, latency time.Duration) error {
+	if latency <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(latency)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-t.C:
+		return nil
+	}
+}
+
+// WrapTask decorates a task so that each call consults the Injector.  Any latency is
+// added first, and then the call either fails with the injected error or invokes the
+// given task.  Injected status codes are ignored.
+func WrapTask[V any](task retry.Task[V], i Injector) retry.Task[V] {
+	return func(ctx context.Context) (v V, err error) {
+		f := i.Fault()
+		if err = delay(ctx, f.Latency); err == nil {
+			if f.Err != nil {
+				err = f.Err
+			} else {
+				v, err = task(ctx)
+			}
+		}
+
+		return
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+type TaskSuite struct {
+	suite.Suite
+
+	calls int
+}
+
+func (suite *TaskSuite) SetupTest() {
+	suite.calls = 0
+}
+
+func (suite *TaskSuite) task(context.Context) (int, error) {
+	suite.calls++
+	return 123, nil
+}
+
+func (suite *TaskSuite) TestNoFault() {
+	v, err := WrapTask(suite.task, Script())(context.Background())
+	suite.Equal(123, v)
+	suite.NoError(err)
+	suite.Equal(1, suite.calls)
+}
+
+func (suite *TaskSuite) TestError() {
+	v, err := WrapTask(suite.task, Script(Fault{Err: ErrInjected}))(context.Background())
+	suite.Zero(v)
+	suite.ErrorIs(err, ErrInjected)
+	suite.Zero(suite.calls)
+}
+
+func (suite *TaskSuite) TestStatusCodeIgnored() {
+	v, err := WrapTask(suite.task, Script(Fault{StatusCode: http.StatusServiceUnavailable}))(context.Background())
+	suite.Equal(123, v)
+	suite.NoError(err)
+	suite.Equal(1, suite.calls)
+}
+
+func (suite *TaskSuite) TestLatency() {
+	start := time.Now()
+	v, err := WrapTask(suite.task, Script(Fault{Latency: 10 * time.Millisecond}))(context.Background())
+	suite.Equal(123, v)
+	suite.NoError(err)
+	suite.GreaterOrEqual(time.Since(start), 10*time.Millisecond)
+}
+
+func (suite *TaskSuite) TestLatencyCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	v, err := WrapTask(suite.task, Script(Fault{Latency: time.Hour}))(ctx)
+	suite.Zero(v)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Zero(suite.calls)
+}
+
+func (suite *TaskSuite) TestSurvivesWithRetries() {
+	runner, err := retry.NewRunner(
+		retry.WithImmediateTimer[int](),
+		retry.WithPolicyFactory[int](retry.Config{
+			Interval:   time.Second,
+			MaxRetries: 2,
+		}),
+	)
+
+	suite.Require().NoError(err)
+	task := WrapTask(suite.task, Script(Fault{Err: ErrInjected}, Fault{Err: errors.New("flaky")}, Fault{}))
+	v, err := runner.Run(context.Background(), task)
+	suite.Equal(123, v)
+	suite.NoError(err)
+	suite.Equal(1, suite.calls)
+}
+
+func TestTask(t *testing.T) {
+	suite.Run(t, new(TaskSuite))
+}
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// ClassifierRule examines an error and decides its Category.  If the rule does not
+// apply to the error, it must return false for ok so that other rules may be consulted.
+type ClassifierRule func(err error) (c Category, ok bool)
+
+// RuleForErrors creates a ClassifierRule that applies to any error matching one of
+// the targets according to errors.Is.
+func RuleForErrors(c Category, targets ...error) ClassifierRule {
+	targets = append([]error(nil), targets...)
+	return func(err error) (Category, bool) {
+		if isAny(err, targets) {
+			return c, true
+		}
+
+		return CategoryUnknown, false
+	}
+}
+
+// RuleForType creates a ClassifierRule that applies to any error that has an error of
+// type T in its chain according to errors.As.
+func RuleForType[T error](c Category) ClassifierRule {
+	return func(err error) (Category, bool) {
+		var target T
+		if errors.As(err, &target) {
+			return c, true
+		}
+
+		return CategoryUnknown, false
+	}
+}
+
+// dnsRule treats a DNS lookup for a host that doesn't exist as permanent.  All other
+// DNS errors, such as timeouts or misbehaving servers, are retried.
+func dnsRule(err error) (Category, bool) {
+	var dnsErr *net.DNSError
+	switch {
+	case !errors.As(err, &dnsErr):
+		return CategoryUnknown, false
+
+	case dnsErr.IsNotFound:
+		return CategoryPermanent, true
+
+	case dnsErr.IsTimeout:
+		return CategoryTimeout, true
+
+	default:
+		return CategoryTransient, true
+	}
+}
+
+// timeoutRule categorizes any net.Error that reports a timeout.
+func timeoutRule(err error) (Category, bool) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return CategoryTimeout, true
+	}
+
+	return CategoryUnknown, false
+}
+
+// StandardRules returns the ClassifierRules that understand common standard library
+// errors, in the order they are consulted:
+//
+//   - context.Canceled is CategoryCanceled
+//   - TLS certificate verification failures are CategoryPermanent
+//   - io.EOF is CategoryPermanent, since it signals the normal end of input rather than
+//     a failure.  Use io.ErrUnexpectedEOF for input that ended prematurely.
+//   - context.DeadlineExceeded, os.ErrDeadlineExceeded, and the syscall error ETIMEDOUT
+//     are CategoryTimeout.  Deadlines set for a single attempt, such as http.Client.Timeout,
+//     produce these errors, so they are retried.  When the context passed to Run reaches
+//     its deadline instead, the retry policy halts the run.
+//   - io.ErrUnexpectedEOF and the syscall errors ECONNRESET, ECONNABORTED, and EPIPE
+//     are CategoryTransient
+//   - the syscall errors ECONNREFUSED, EHOSTUNREACH, and ENETUNREACH are CategoryUnavailable
+//   - a *net.DNSError is CategoryPermanent if the host was not found, CategoryTimeout if
+//     the lookup timed out, and CategoryTransient otherwise
+//   - a net.Error whose Timeout method returns true is CategoryTimeout
+//
+// The deprecated net.Error Temporary method is not consulted.
+func StandardRules() []ClassifierRule {
+	return []ClassifierRule{
+		RuleForErrors(CategoryCanceled, context.Canceled),
+		RuleForType[*tls.CertificateVerificationError](CategoryPermanent),
+		RuleForErrors(CategoryPermanent, io.EOF),
+		RuleForErrors(
+			CategoryTimeout,
+			context.DeadlineExceeded,
+			os.ErrDeadlineExceeded,
+			syscall.ETIMEDOUT,
+		),
+		RuleForErrors(
+			CategoryTransient,
+			io.ErrUnexpectedEOF,
+			syscall.ECONNRESET,
+			syscall.ECONNABORTED,
+			syscall.EPIPE,
+		),
+		RuleForErrors(
+			CategoryUnavailable,
+			syscall.ECONNREFUSED,
+			syscall.EHOSTUNREACH,
+			syscall.ENETUNREACH,
+		),
+		dnsRule,
+		timeoutRule,
+	}
+}
+
+// Classifier is a registry of ClassifierRules that decides the Category of errors, and
+// thus whether they should be retried.  A Classifier is safe for concurrent use, including
+// registering new rules while errors are being classified.
+//
+// An error that no rule applies to is CategoryUnknown by default, which is retried.  Most
+// errors returned by application code fall into this category, so this default keeps the
+// behavior of DefaultTestErrorForRetry for them.  Use SetFallback with CategoryPermanent to
+// retry only the errors that a rule, or the error itself, declares to be retryable.
+type Classifier struct {
+	lock     sync.RWMutex
+	rules    []ClassifierRule
+	fallback Category
+}
+
+// NewClassifier creates a Classifier with the given rules, which are consulted in order.
+// Use NewClassifier(StandardRules()...) to start with the standard library rules.
+func NewClassifier(rules ...ClassifierRule) *Classifier {
+	return &Classifier{
+		rules: append([]ClassifierRule(nil), rules...),
+	}
+}
+
+// Register adds rules to this Classifier.  The new rules are consulted, in the order
+// given, before any rules that were previously registered.  This allows callers to
+// override the standard rules.
+func (c *Classifier) Register(rules ...ClassifierRule) {
+	defer c.lock.Unlock()
+	c.lock.Lock()
+	c.rules = append(append([]ClassifierRule(nil), rules...), c.rules...)
+}
+
+// SetFallback changes the Category of errors that no rule applies to.  Passing
+// CategoryNone restores the default, CategoryUnknown.
+func (c *Classifier) SetFallback(category Category) {
+	defer c.lock.Unlock()
+	c.lock.Lock()
+	c.fallback = category
+}
+
+// Categorize determines the Category of an error using the following logic:
+//
+//   - if err == nil, CategoryNone is returned
+//   - if err declares its own category, via Categorizer or ShouldRetryable, that is used.
+//     A ShouldRetryable error is CategoryTransient if retryable, CategoryPermanent otherwise.
+//   - otherwise, the first rule that applies to err decides
+//   - failing any applicable rule, this method returns the fallback category, which is
+//     CategoryUnknown unless changed with SetFallback
+func (c *Classifier) Categorize(err error) Category {
+	if err == nil {
+		return CategoryNone
+	}
+
+	if category, ok := declaredCategory(err); ok {
+		return category
+	}
+
+	c.lock.RLock()
+	rules, fallback := c.rules, c.fallback
+	c.lock.RUnlock()
+
+	for _, rule := range rules {
+		if category, ok := rule(err); ok {
+			return category
+		}
+	}
+
+	if fallback == CategoryNone {
+		return CategoryUnknown
+	}
+
+	return fallback
+}
+
+// ShouldRetry determines whether an error should be retried based on its Category.
+// Successful results and uncategorized errors are retried according to Category.Retryable,
+// i.e. a nil error is not retried while an unknown error is, unless SetFallback was used.
+func (c *Classifier) ShouldRetry(err error) bool {
+	return c.Categorize(err).Retryable()
+}
+
+// DefaultClassifier is the package-wide Classifier that starts with the StandardRules.
+// Applications may Register additional rules with it, typically at startup.
+var DefaultClassifier = NewClassifier(StandardRules()...)
+
+// ClassifyForRetry uses the DefaultClassifier to determine whether an error should be
+// retried.  Unlike DefaultTestErrorForRetry, this function treats context cancelation as
+// permanent and understands the common transient errors from the net, os, io, and syscall
+// packages.
+func ClassifyForRetry(err error) bool {
+	return DefaultClassifier.ShouldRetry(err)
+}
+
+// WithClassifier uses the given Classifier as the created task runner's ShouldRetry strategy.
+// The task's value result is not considered.  If c is nil, DefaultClassifier is used.
+//
+// The Classifier is also used to set the Category of each Attempt.  Runners that do not use
+// this option categorize attempts with the DefaultClassifier.
+//
+// This option, WithShouldRetry, and WithShouldRetryAttempt replace each other.  The last
+// one applied is used, although the Classifier will still be used to categorize attempts.
+func WithClassifier[V any](c *Classifier) RunnerOption[V] {
+	if c == nil {
+		c = DefaultClassifier
+	}
+
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.classifier = c
+		r.shouldRetry = func(a Attempt[V]) bool {
+			return c.ShouldRetry(a.Err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClassifierSuite struct {
+	CommonSuite
+}
+
+type testTimeoutError struct {
+	timeout bool
+}
+
+func (tte testTimeoutError) Error() string   { return "test timeout error" }
+func (tte testTimeoutError) Timeout() bool   { return tte.timeout }
+func (tte testTimeoutError) Temporary() bool { return true }
+
+func (suite *ClassifierSuite) TestStandardRules() {
+	c := NewClassifier(StandardRules()...)
+	testCases := []struct {
+		label    string
+		err      error
+		expected Category
+	}{
+		{label: "Nil", err: nil, expected: CategoryNone},
+		{label: "Canceled", err: context.Canceled, expected: CategoryCanceled},
+		{label: "DeadlineExceeded", err: context.DeadlineExceeded, expected: CategoryTimeout},
+		{label: "WrappedCanceled", err: &net.OpError{Op: "dial", Err: context.Canceled}, expected: CategoryCanceled},
+		{label: "Certificate", err: &tls.CertificateVerificationError{Err: errors.New("bad cert")}, expected: CategoryPermanent},
+		{label: "OSDeadlineExceeded", err: fmt.Errorf("read: %w", os.ErrDeadlineExceeded), expected: CategoryTimeout},
+		{label: "UnexpectedEOF", err: io.ErrUnexpectedEOF, expected: CategoryTransient},
+		{label: "EOF", err: io.EOF, expected: CategoryPermanent},
+		{label: "ECONNRESET", err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, expected: CategoryTransient},
+		{label: "ECONNREFUSED", err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, expected: CategoryUnavailable},
+		{label: "EPIPE", err: syscall.EPIPE, expected: CategoryTransient},
+		{label: "ETIMEDOUT", err: syscall.ETIMEDOUT, expected: CategoryTimeout},
+		{label: "DNSNotFound", err: &net.DNSError{IsNotFound: true, IsTemporary: true}, expected: CategoryPermanent},
+		{label: "DNSTimeout", err: &net.DNSError{IsTimeout: true}, expected: CategoryTimeout},
+		{label: "DNSOther", err: &net.DNSError{}, expected: CategoryTransient},
+		{label: "NetTimeout", err: testTimeoutError{timeout: true}, expected: CategoryTimeout},
+		{label: "TemporaryIgnored", err: testTimeoutError{timeout: false}, expected: CategoryUnknown},
+		{label: "Retryable", err: SetRetryable(context.Canceled, true), expected: CategoryTransient},
+		{label: "NotRetryable", err: SetRetryable(io.EOF, false), expected: CategoryPermanent},
+		{label: "Declared", err: SetCategory(io.EOF, CategoryThrottled), expected: CategoryThrottled},
+		{label: "Unknown", err: errors.New("unknown"), expected: CategoryUnknown},
+	}
+
+	for _, testCase := range testCases {
+		suite.Run(testCase.label, func() {
+			suite.Equal(testCase.expected, c.Categorize(testCase.err))
+			suite.Equal(testCase.expected.Retryable(), c.ShouldRetry(testCase.err))
+		})
+	}
+}
+
+func (suite *ClassifierSuite) TestRegister() {
+	var (
+		customErr = errors.New("custom")
+		c         = NewClassifier(StandardRules()...)
+	)
+
+	suite.True(c.ShouldRetry(customErr))
+	suite.True(c.ShouldRetry(io.ErrUnexpectedEOF))
+
+	c.Register(
+		RuleForErrors(CategoryPermanent, customErr, io.ErrUnexpectedEOF),
+		RuleForType[*net.DNSError](CategoryThrottled),
+	)
+
+	suite.False(c.ShouldRetry(customErr))
+	suite.False(c.ShouldRetry(io.ErrUnexpectedEOF), "registered rules override the standard rules")
+	suite.Equal(CategoryThrottled, c.Categorize(&net.DNSError{IsTimeout: true}))
+	suite.True(c.ShouldRetry(syscall.ECONNRESET))
+}
+
+func (suite *ClassifierSuite) TestSetFallback() {
+	var (
+		unknownErr = errors.New("unknown")
+		c          = NewClassifier(StandardRules()...)
+	)
+
+	suite.Equal(CategoryUnknown, c.Categorize(unknownErr))
+	suite.True(c.ShouldRetry(unknownErr), "unknown errors are retried by default")
+
+	c.SetFallback(CategoryPermanent)
+	suite.Equal(CategoryPermanent, c.Categorize(unknownErr))
+	suite.False(c.ShouldRetry(unknownErr))
+	suite.True(c.ShouldRetry(io.ErrUnexpectedEOF), "rules must still apply")
+	suite.True(c.ShouldRetry(SetRetryable(unknownErr, true)), "declared categories must still apply")
+	suite.False(c.ShouldRetry(nil))
+
+	c.SetFallback(CategoryNone)
+	suite.Equal(CategoryUnknown, c.Categorize(unknownErr))
+}
+
+func (suite *ClassifierSuite) TestNoRules() {
+	c := NewClassifier()
+	suite.False(c.ShouldRetry(nil))
+	suite.True(c.ShouldRetry(context.Canceled))
+}
+
+func (suite *ClassifierSuite) TestClassifyForRetry() {
+	suite.False(ClassifyForRetry(nil))
+	suite.False(ClassifyForRetry(context.Canceled))
+	suite.False(ClassifyForRetry(io.EOF), "io.EOF is the normal end of input")
+	suite.True(ClassifyForRetry(io.ErrUnexpectedEOF))
+}
+
+func (suite *ClassifierSuite) TestWithClassifier() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		c          = NewClassifier(RuleForErrors(CategoryPermanent, io.EOF))
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithClassifier[int](c),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, io.ErrUnexpectedEOF).Once()
+	task.ExpectMatch(suite.assertTestCtx, -1, io.EOF).Once()
+
+	_, err := runner.Run(testCtx, task.Do)
+	suite.ErrorIs(err, io.EOF)
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *ClassifierSuite) TestWithDefaultClassifier() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithClassifier[int](nil),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, context.DeadlineExceeded).Once()
+	task.ExpectMatch(suite.assertTestCtx, -1, context.Canceled).Once()
+
+	_, err := runner.Run(testCtx, task.Do)
+	suite.ErrorIs(err, context.Canceled)
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *ClassifierSuite) TestHTTPClientTimeout() {
+	var (
+		done   = make(chan struct{})
+		server = httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+			select {
+			case <-request.Context().Done():
+			case <-done:
+			}
+		}))
+
+		client = &http.Client{Timeout: 20 * time.Millisecond}
+	)
+
+	defer server.Close()
+	defer close(done)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	suite.Require().NoError(err)
+
+	response, err := client.Do(request)
+	suite.Nil(response)
+	suite.Require().Error(err)
+	suite.Equal(CategoryTimeout, DefaultClassifier.Categorize(err))
+	suite.True(ClassifyForRetry(err), "a client timeout must be retried")
+}
+
+func TestClassifier(t *testing.T) {
+	suite.Run(t, new(ClassifierSuite))
+}
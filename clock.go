@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time for policies and runners.  The default Clock delegates
+// to the time and context packages.  A custom Clock, such as the fake clock in the
+// retrytest package, is primarily useful in unit tests.
+type Clock interface {
+	// Now returns the current time according to this Clock.
+	Now() time.Time
+
+	// NewTimer starts a timer that fires after d has elapsed.  The returned values
+	// have the same semantics as Timer.
+	NewTimer(d time.Duration) (ch <-chan time.Time, stop func() bool)
+
+	// WithDeadline creates a child context that is done when this Clock reaches the
+	// given deadline.  The returned context must behave like context.WithDeadline,
+	// including returning context.DeadlineExceeded from Err when the deadline passes.
+	WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc)
+}
+
+// SystemClock is the Clock that delegates to the time and context packages.  This is the
+// Clock used by default.
+//
+// Since SystemClock only uses the time and context packages, and since a Runner starts no
+// goroutines of its own except in RunAsync and for hedged attempts, runners and Config
+// policies work inside a testing/synctest bubble.  Retry intervals and MaxElapsedTime are
+// then measured with the bubble's fake time, so no custom Timer or Clock is needed.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	return defaultTimer(d)
+}
+
+func (systemClock) WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(parent, deadline)
+}
+
+type clockContextKey struct{}
+
+// ContextWithClock returns a child context that carries the given Clock.  PolicyFactory
+// implementations, including Config, use the Clock returned by ClockFromContext to
+// enforce time limits.  Runners created with WithClock do this automatically.
+func ContextWithClock(parent context.Context, c Clock) context.Context {
+	return context.WithValue(parent, clockContextKey{}, c)
+}
+
+// ClockFromContext returns the Clock carried by the given context.  If the context
+// has no Clock, SystemClock is returned.
+func ClockFromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockContextKey{}).(Clock); ok && c != nil {
+		return c
+	}
+
+	return SystemClock
+}
+
+// WithClock sets the Clock used by the created task runner to measure elapsed time and
+// to wait between retries.  The Clock is also passed to the PolicyFactory via the context,
+// so that time limits such as Config.MaxElapsedTime use the same Clock.
+//
+// Any deadline on the policy context, including one inherited from the context passed to
+// Run, is compared against this Clock before each attempt.
+//
+// If WithTimer or WithImmediateTimer is also used, that Timer is used to wait between
+// retries instead of this Clock.  If c is nil, SystemClock is used.
+func WithClock[V any](c Clock) RunnerOption[V] {
+	if c == nil {
+		c = SystemClock
+	}
+
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.clock = c
+		return nil
+	})
+}
+
+// clockContextErr returns ctx.Err(), or context.DeadlineExceeded if the context's deadline
+// has passed according to the given Clock even though the context isn't done yet.
+func clockContextErr(ctx context.Context, c Clock) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && !c.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// stoppedClock is a Clock whose time never moves.  Its deadlines are recorded, but never fire.
+type stoppedClock struct {
+	now       time.Time
+	deadlines []time.Time
+}
+
+func (sc *stoppedClock) Now() time.Time { return sc.now }
+
+func (sc *stoppedClock) NewTimer(time.Duration) (<-chan time.Time, func() bool) {
+	return immediateTimer(0)
+}
+
+func (sc *stoppedClock) WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	sc.deadlines = append(sc.deadlines, deadline)
+	return context.WithCancel(parent)
+}
+
+type ClockSuite struct {
+	CommonSuite
+}
+
+func (suite *ClockSuite) TestSystemClock() {
+	before := time.Now()
+	suite.False(SystemClock.Now().Before(before))
+
+	ctx, cancel := SystemClock.WithDeadline(context.Background(), before.Add(time.Hour))
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	suite.True(ok)
+	suite.Equal(before.Add(time.Hour), deadline)
+
+	ch, stop := SystemClock.NewTimer(time.Millisecond)
+	defer stop()
+	select {
+	case <-ch:
+		// passing
+	case <-time.After(time.Second):
+		suite.Fail("the timer did not fire")
+	}
+}
+
+func (suite *ClockSuite) TestClockFromContext() {
+	suite.Equal(SystemClock, ClockFromContext(context.Background()))
+	suite.Equal(SystemClock, ClockFromContext(ContextWithClock(context.Background(), nil)))
+
+	sc := new(stoppedClock)
+	suite.Same(sc, ClockFromContext(ContextWithClock(context.Background(), sc)))
+}
+
+func (suite *ClockSuite) TestConfigMaxElapsedTime() {
+	var (
+		now = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+		sc  = &stoppedClock{now: now}
+		p   = Config{
+			Interval:       time.Second,
+			MaxElapsedTime: time.Hour,
+		}.NewPolicy(ContextWithClock(context.Background(), sc))
+	)
+
+	defer p.Cancel()
+	suite.Equal([]time.Time{now.Add(time.Hour)}, sc.deadlines)
+}
+
+func (suite *ClockSuite) TestWithClock() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		onAttempt  = new(mockOnAttempt[int])
+		retryErr   = errors.New("should retry this")
+		sc         = &stoppedClock{now: time.Now()}
+		runner     = suite.newRunner(
+			WithClock[int](sc),
+			WithOnAttempt(onAttempt.OnAttempt),
+			WithPolicyFactory[int](Config{
+				Interval:       time.Hour,
+				MaxElapsedTime: 24 * time.Hour,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, retryErr).Once()
+	task.ExpectMatch(
+		func(ctx context.Context) bool {
+			return suite.assertTestCtx(ctx) && suite.Same(sc, ClockFromContext(ctx))
+		},
+		123,
+		nil,
+	).Once()
+
+	onAttempt.ExpectMatch(func(a Attempt[int]) bool {
+		return a.Elapsed == 0
+	}).Twice()
+
+	result, err := runner.Run(testCtx, task.Do)
+	suite.NoError(err)
+	suite.Equal(123, result)
+	suite.Len(sc.deadlines, 1)
+
+	task.AssertExpectations(suite.T())
+	onAttempt.AssertExpectations(suite.T())
+}
+
+func TestClock(t *testing.T) {
+	suite.Run(t, new(ClockSuite))
+}
@@ -44,6 +44,8 @@ type Config struct {
 	// MaxElapsedTime is the absolute amount of time an operation and its retries are
 	// allowed to take before giving up.  If this field is nonpositive, no maximum
 	// elapsed time is enforced.
+	//
+	// This limit is measured with the Clock returned by ClockFromContext.
 	MaxElapsedTime time.Duration `json:"maxElapsedTime" yaml:"maxElapsedTime"`
 
 	// MaxInterval is the upper limit for each retry interval for an exponential backoff.
@@ -52,9 +54,12 @@ type Config struct {
 	MaxInterval time.Duration `json:"maxInterval" yaml:"maxInterval"`
 }
 
+// newPolicyCtx creates the policy context, enforcing MaxElapsedTime with the
+// Clock carried by the parent context.
 func (c Config) newPolicyCtx(parentCtx context.Context) (context.Context, context.CancelFunc) {
 	if c.MaxElapsedTime > 0 {
-		return context.WithTimeout(parentCtx, c.MaxElapsedTime)
+		clock := ClockFromContext(parentCtx)
+		return clock.WithDeadline(parentCtx, clock.Now().Add(c.MaxElapsedTime))
 	}
 
 	return context.WithCancel(parentCtx)
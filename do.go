@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import "context"
+
+// newDoRunner creates the Runner used by the Do functions.  The PolicyFactory is applied
+// first, so that the options may override it.
+func newDoRunner[V any](pf PolicyFactory, opts []RunnerOption[V]) (Runner[V], error) {
+	return NewRunner(
+		append([]RunnerOption[V]{WithPolicyFactory[V](pf)}, opts...)...,
+	)
+}
+
+// Do executes a task once, retrying it as indicated by the PolicyFactory, and returns
+// the result of the last attempt.  This function is a shorthand for creating a Runner
+// and calling Run, which is useful for a single call site.  Code that executes the same
+// kind of task repeatedly should create a Runner instead.
+//
+// As with AsTask, the type parameter V must usually be supplied explicitly, e.g.
+// Do[int](ctx, pf, task).
+//
+// If pf is nil, the task is never retried.  Any options are applied after the PolicyFactory,
+// and an error from an option is returned without executing the task.
+func Do[V any, RT RunnableTask[V]](ctx context.Context, pf PolicyFactory, rt RT, opts ...RunnerOption[V]) (V, error) {
+	runner, err := newDoRunner(pf, opts)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	return runner.Run(ctx, AsTask[V](rt))
+}
+
+// DoSimple is like Do, but for a task that only returns an error.  Since such a task
+// has no result, the options are for a Runner of struct{}, e.g.
+// WithShouldRetry[struct{}](...).
+func DoSimple[T SimpleTask](ctx context.Context, pf PolicyFactory, task T, opts ...RunnerOption[struct{}]) error {
+	_, err := Do(ctx, pf, AddZero[struct{}](task), opts...)
+	return err
+}
+
+// DoValue is like Do, but for a task that only returns an error.  The given result is
+// returned along with the error of the last attempt, as with AddValue.
+func DoValue[V any, T SimpleTask](ctx context.Context, pf PolicyFactory, result V, task T, opts ...RunnerOption[V]) (V, error) {
+	return Do(ctx, pf, AddValue(result, task), opts...)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func ExampleDo() {
+	attempts := 0
+	result, err := Do[int](
+		context.Background(),
+		Config{
+			Interval: 10 * time.Millisecond,
+		},
+		func() (int, error) {
+			fmt.Println("executing task ...")
+			attempts++
+			if attempts < 2 {
+				return -1, errors.New("task error")
+			}
+
+			return 1234, nil
+		},
+	)
+
+	fmt.Println("Do result", result, err)
+
+	// Output:
+	// executing task ...
+	// executing task ...
+	// Do result 1234 <nil>
+}
+
+func ExampleDoSimple() {
+	err := DoSimple(
+		context.Background(),
+		Config{
+			Interval:   10 * time.Millisecond,
+			MaxRetries: 1,
+		},
+		func(context.Context) error {
+			fmt.Println("executing task ...")
+			return errors.New("task error")
+		},
+	)
+
+	fmt.Println("DoSimple error:", err)
+
+	// Output:
+	// executing task ...
+	// executing task ...
+	// DoSimple error: task error
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DoSuite struct {
+	CommonSuite
+
+	retryErr error
+	pf       PolicyFactory
+}
+
+func (suite *DoSuite) SetupTest() {
+	suite.retryErr = errors.New("should retry this")
+	suite.pf = Config{
+		Interval:   time.Millisecond,
+		MaxRetries: 2,
+	}
+}
+
+// failTwice returns a SimpleTask that fails on its first two calls.
+func (suite *DoSuite) failTwice(calls *int) func(context.Context) error {
+	return func(context.Context) error {
+		if *calls++; *calls < 3 {
+			return suite.retryErr
+		}
+
+		return nil
+	}
+}
+
+func (suite *DoSuite) TestDo() {
+	testCtx, _ := suite.testCtx()
+
+	suite.Run("WithContext", func() {
+		calls := 0
+		result, err := Do[int](testCtx, suite.pf, func(ctx context.Context) (int, error) {
+			suite.NotNil(ctx)
+			if calls++; calls < 3 {
+				return -1, suite.retryErr
+			}
+
+			return 123, nil
+		})
+
+		suite.NoError(err)
+		suite.Equal(123, result)
+		suite.Equal(3, calls)
+	})
+
+	suite.Run("WithoutContext", func() {
+		calls := 0
+		result, err := Do[string](testCtx, suite.pf, func() (string, error) {
+			calls++
+			return "", suite.retryErr
+		})
+
+		suite.ErrorIs(err, suite.retryErr)
+		suite.Empty(result)
+		suite.Equal(3, calls)
+	})
+
+	suite.Run("NilPolicyFactory", func() {
+		calls := 0
+		_, err := Do[int](testCtx, nil, func() (int, error) {
+			calls++
+			return -1, suite.retryErr
+		})
+
+		suite.ErrorIs(err, suite.retryErr)
+		suite.Equal(1, calls)
+	})
+
+	suite.Run("Options", func() {
+		var attempts []Attempt[int]
+		_, err := Do[int](
+			testCtx,
+			suite.pf,
+			AddZero[int](suite.failTwice(new(int))),
+			WithImmediateTimer[int](),
+			WithOnAttempt(func(a Attempt[int]) {
+				attempts = append(attempts, a)
+			}),
+		)
+
+		suite.NoError(err)
+		suite.Len(attempts, 3)
+	})
+}
+
+// namedSimpleTask is a named SimpleTask type, which must be usable with the Do functions.
+type namedSimpleTask func() error
+
+func (suite *DoSuite) TestNamedTypes() {
+	testCtx, _ := suite.testCtx()
+
+	suite.Run("Task", func() {
+		calls := 0
+		task := Task[int](func(context.Context) (int, error) {
+			if calls++; calls < 3 {
+				return -1, suite.retryErr
+			}
+
+			return 123, nil
+		})
+
+		result, err := Do[int](testCtx, suite.pf, task, WithImmediateTimer[int]())
+		suite.NoError(err)
+		suite.Equal(123, result)
+		suite.Equal(3, calls)
+	})
+
+	suite.Run("SimpleTask", func() {
+		calls := 0
+		task := namedSimpleTask(func() error {
+			if calls++; calls < 3 {
+				return suite.retryErr
+			}
+
+			return nil
+		})
+
+		suite.NoError(DoSimple(testCtx, suite.pf, task))
+		suite.Equal(3, calls)
+
+		calls = 0
+		result, err := DoValue(testCtx, suite.pf, "done", task)
+		suite.NoError(err)
+		suite.Equal("done", result)
+		suite.Equal(3, calls)
+	})
+}
+
+func (suite *DoSuite) TestDoOptionError() {
+	var (
+		testCtx, _ = suite.testCtx()
+		optionErr  = errors.New("expected")
+		badOption  = runnerOptionFunc[int](func(*runner[int]) error {
+			return optionErr
+		})
+	)
+
+	result, err := Do[int](testCtx, suite.pf, func() (int, error) {
+		suite.Fail("the task should not be called")
+		return 123, nil
+	}, badOption)
+
+	suite.ErrorIs(err, optionErr)
+	suite.Zero(result)
+}
+
+func (suite *DoSuite) TestDoSimple() {
+	testCtx, _ := suite.testCtx()
+
+	suite.Run("WithContext", func() {
+		calls := 0
+		suite.NoError(DoSimple(testCtx, suite.pf, suite.failTwice(&calls), WithImmediateTimer[struct{}]()))
+		suite.Equal(3, calls)
+	})
+
+	suite.Run("WithoutContext", func() {
+		calls := 0
+		err := DoSimple(testCtx, suite.pf, func() error {
+			calls++
+			return suite.retryErr
+		})
+
+		suite.ErrorIs(err, suite.retryErr)
+		suite.Equal(3, calls)
+	})
+}
+
+func (suite *DoSuite) TestDoValue() {
+	testCtx, _ := suite.testCtx()
+
+	suite.Run("WithContext", func() {
+		calls := 0
+		result, err := DoValue(testCtx, suite.pf, "done", suite.failTwice(&calls))
+		suite.NoError(err)
+		suite.Equal("done", result)
+		suite.Equal(3, calls)
+	})
+
+	suite.Run("WithoutContext", func() {
+		calls := 0
+		result, err := DoValue(testCtx, suite.pf, 123, func() error {
+			calls++
+			return suite.retryErr
+		}, WithImmediateTimer[int]())
+
+		suite.ErrorIs(err, suite.retryErr)
+		suite.Equal(123, result)
+		suite.Equal(3, calls)
+	})
+}
+
+func TestDo(t *testing.T) {
+	suite.Run(t, new(DoSuite))
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is the eventual result of a task submitted to Runner.RunAsync.  A Future
+// is safe for concurrent use.
+type Future[V any] struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	lock     sync.RWMutex
+	last     Attempt[V]
+	attempts int
+
+	// result and err are only written prior to closing done
+	result V
+	err    error
+}
+
+func newFuture[V any](cancel context.CancelFunc) *Future[V] {
+	return &Future[V]{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+}
+
+// RunAsync executes a task in the background using any Runner.  For a Runner created
+// by NewRunner, this is the same as calling its RunAsync method.  For any other
+// implementation, the task is executed by calling Run in a goroutine, and the returned
+// Future does not report the progress of attempts.
+//
+// Implementations of Runner outside this package can use this function to implement
+// the RunAsync method:
+//
+//	func (s *stubRunner) RunAsync(ctx context.Context, task retry.Task[int]) *retry.Future[int] {
+//		return retry.RunAsync[int](ctx, s, task)
+//	}
+func RunAsync[V any](parentCtx context.Context, r Runner[V], task Task[V]) *Future[V] {
+	if rr, ok := r.(*runner[V]); ok {
+		return rr.RunAsync(parentCtx, task)
+	}
+
+	ctx, cancel := context.WithCancel(parentCtx)
+	f := newFuture[V](cancel)
+	go func() {
+		defer cancel()
+		f.complete(r.Run(ctx, task))
+	}()
+
+	return f
+}
+
+// onAttempt records the progress of the background task.
+func (f *Future[V]) onAttempt(a Attempt[V]) {
+	defer f.lock.Unlock()
+	f.lock.Lock()
+
+	f.last = a
+	f.attempts++
+}
+
+// complete records the final result of the background task and signals
+// any waiting goroutines.
+func (f *Future[V]) complete(result V, err error) {
+	f.result = result
+	f.err = err
+	close(f.done)
+}
+
+// Done returns a channel that is closed when the task has finished, whether
+// it succeeded, exhausted its retries, or was canceled.
+func (f *Future[V]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel halts the task and any further retries.  This method is idempotent,
+// and it does not wait for the task to finish.  Use Wait or Done for that.
+func (f *Future[V]) Cancel() {
+	f.cancel()
+}
+
+// Wait blocks until the task finishes, returning the same result and error that
+// Runner.Run would have.  If the given context is canceled first, this method returns
+// the zero value for V along with ctx.Err().  Canceling this method's context does not
+// cancel the task.
+//
+// The context passed to this method must never be nil.
+func (f *Future[V]) Wait(ctx context.Context) (result V, err error) {
+	select {
+	case <-f.done:
+		result, err = f.result, f.err
+
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return
+}
+
+// Last returns the most recent attempt made by the task.  If no attempt has
+// completed yet, this method returns false.
+func (f *Future[V]) Last() (Attempt[V], bool) {
+	defer f.lock.RUnlock()
+	f.lock.RLock()
+	return f.last, f.attempts > 0
+}
+
+// Attempts returns the number of attempts that have completed so far, including
+// a successful one.
+func (f *Future[V]) Attempts() int {
+	defer f.lock.RUnlock()
+	f.lock.RLock()
+	return f.attempts
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FutureSuite struct {
+	CommonSuite
+}
+
+// requireDone fails the enclosing test if the future does not complete in a reasonable time.
+func (suite *FutureSuite) requireDone(f *Future[int]) {
+	select {
+	case <-f.Done():
+		// passing
+	case <-time.After(time.Second):
+		suite.FailNow("the future did not complete")
+	}
+}
+
+func (suite *FutureSuite) TestSuccess() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		retryErr   = errors.New("should retry this")
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithPolicyFactory[int](Config{
+				Interval: 5 * time.Second,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, retryErr).Times(2)
+	task.ExpectMatch(suite.assertTestCtx, 123, nil).Once()
+
+	f := runner.RunAsync(testCtx, task.Do)
+	suite.Require().NotNil(f)
+
+	result, err := f.Wait(context.Background())
+	suite.Equal(123, result)
+	suite.NoError(err)
+	suite.requireDone(f)
+
+	suite.Equal(3, f.Attempts())
+	last, ok := f.Last()
+	suite.True(ok)
+	suite.Equal(123, last.Result)
+	suite.NoError(last.Err)
+	suite.Equal(2, last.Retries)
+
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *FutureSuite) TestCancel() {
+	var (
+		testCtx, _ = suite.testCtx()
+		started    = make(chan struct{})
+		runner     = suite.newRunner()
+	)
+
+	f := runner.RunAsync(testCtx, func(ctx context.Context) (int, error) {
+		suite.assertTestCtx(ctx)
+		close(started)
+		<-ctx.Done()
+		return -1, ctx.Err()
+	})
+
+	suite.Require().NotNil(f)
+	<-started
+	_, ok := f.Last()
+	suite.False(ok)
+	suite.Zero(f.Attempts())
+
+	f.Cancel()
+	suite.requireDone(f)
+	f.Cancel() // idempotent
+
+	_, err := f.Wait(context.Background())
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *FutureSuite) TestParentCanceled() {
+	var (
+		testCtx, testCancel = suite.testCtx()
+		runner              = suite.newRunner()
+	)
+
+	f := runner.RunAsync(testCtx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return -1, ctx.Err()
+	})
+
+	suite.Require().NotNil(f)
+	testCancel()
+	suite.requireDone(f)
+
+	_, err := f.Wait(context.Background())
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *FutureSuite) TestWaitCanceled() {
+	var (
+		testCtx, _ = suite.testCtx()
+		release    = make(chan struct{})
+		runner     = suite.newRunner()
+	)
+
+	f := runner.RunAsync(testCtx, func(context.Context) (int, error) {
+		<-release
+		return 123, nil
+	})
+
+	suite.Require().NotNil(f)
+
+	waitCtx, waitCancel := context.WithCancel(context.Background())
+	waitCancel()
+	result, err := f.Wait(waitCtx)
+	suite.Zero(result)
+	suite.ErrorIs(err, context.Canceled)
+
+	// the task itself must not have been canceled
+	close(release)
+	result, err = f.Wait(context.Background())
+	suite.Equal(123, result)
+	suite.NoError(err)
+}
+
+// stubRunner is a Runner implemented outside of NewRunner, as a client of this package would.
+type stubRunner struct {
+	result int
+	err    error
+}
+
+func (sr stubRunner) Run(ctx context.Context, _ Task[int]) (int, error) {
+	<-ctx.Done()
+	return sr.result, sr.err
+}
+
+func (sr stubRunner) RunAsync(ctx context.Context, task Task[int]) *Future[int] {
+	return RunAsync[int](ctx, sr, task)
+}
+
+func (suite *FutureSuite) TestRunAsync() {
+	suite.Run("NewRunner", func() {
+		testCtx, _ := suite.testCtx()
+		runner := suite.newRunner()
+		f := RunAsync(testCtx, runner, func(context.Context) (int, error) {
+			return 123, nil
+		})
+
+		result, err := f.Wait(context.Background())
+		suite.Equal(123, result)
+		suite.NoError(err)
+		suite.Equal(1, f.Attempts(), "progress must be reported for a Runner created by NewRunner")
+	})
+
+	suite.Run("Stub", func() {
+		testCtx, _ := suite.testCtx()
+		expectedErr := errors.New("expected")
+		var runner Runner[int] = stubRunner{result: -1, err: expectedErr}
+		f := runner.RunAsync(testCtx, nil)
+
+		select {
+		case <-f.Done():
+			suite.Fail("the stub must run until the future is canceled")
+		default:
+		}
+
+		f.Cancel()
+		suite.requireDone(f)
+		result, err := f.Wait(context.Background())
+		suite.Equal(-1, result)
+		suite.Same(expectedErr, err)
+		suite.Zero(f.Attempts())
+	})
+}
+
+func TestFuture(t *testing.T) {
+	suite.Run(t, new(FutureSuite))
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// HedgeCleanup is a callback for the results of hedged attempts that did not win.
+// That includes attempts that completed after another concurrent attempt had already
+// won, as well as failures that may be retried which are superseded by a later result
+// from the same group.  Only the last of those failures is returned from the group,
+// so it is never passed to this callback.  Typical uses include closing HTTP response
+// bodies.
+//
+// This function may be invoked from a separate goroutine, and it must not block.
+type HedgeCleanup[V any] func(V, error)
+
+// WithHedging enables speculative, hedged attempts for the created task runner.
+// When an attempt has not completed within delay, another attempt is started
+// concurrently without canceling the first.  At most maxInFlight attempts will
+// execute concurrently.  A failed attempt that may be retried causes the next
+// hedged attempt to start immediately, if any remain.
+//
+// The first attempt whose result would not be retried wins, and all other attempts
+// are canceled through their contexts.  The results of the losing attempts are passed
+// to any HedgeCleanup callbacks.  From the point of view of the retry policy and any
+// OnAttempt callbacks, a group of hedged attempts is a single attempt.
+//
+// Hedging is only appropriate for idempotent tasks.  If maxInFlight is less than 2,
+// hedging is disabled.  A nonpositive delay starts all hedged attempts at once.
+//
+// The delay is measured with the same Timer or Clock that the runner uses to wait
+// between retries.  In particular, WithImmediateTimer causes every hedged attempt
+// to start at once, regardless of delay.
+func WithHedging[V any](delay time.Duration, maxInFlight int) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.hedgeDelay = delay
+		r.hedgeMaxInFlight = maxInFlight
+		return nil
+	})
+}
+
+// WithHedgeCleanup appends one or more callbacks for the results of losing hedged
+// attempts.  This option can be applied repeatedly, and the set of HedgeCleanup
+// callbacks is cumulative.
+func WithHedgeCleanup[V any](fns ...HedgeCleanup[V]) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.hedgeCleanups = append(r.hedgeCleanups, fns...)
+		return nil
+	})
+}
+
+// hedgeResult is the outcome of a single hedged attempt.
+type hedgeResult[V any] struct {
+	result V
+	err    error
+}
+
+// hedgeGroup tracks the state of a single group of concurrent, hedged attempts.
+type hedgeGroup[V any] struct {
+	r        *runner[V]
+	rs       *runState[V]
+	task     Task[V]
+	ctx      context.Context
+	results  chan hedgeResult[V]
+	launched int
+	pending  int
+}
+
+func (hg *hedgeGroup[V]) launch() {
+	hg.launched++
+	hg.pending++
+	go func() {
+		result, err := hg.task(hg.ctx)
+		hg.results <- hedgeResult[V]{result: result, err: err}
+	}()
+}
+
+// canLaunch tests if another hedged attempt is allowed to start.
+func (hg *hedgeGroup[V]) canLaunch() bool {
+	return hg.launched < hg.r.hedgeMaxInFlight && hg.ctx.Err() == nil
+}
+
+// startTimer starts the hedge delay timer if another hedged attempt is allowed.
+// The returned channel is nil if no further attempts may start.
+func (hg *hedgeGroup[V]) startTimer() (<-chan time.Time, func() bool) {
+	if !hg.canLaunch() {
+		return nil, nopStop
+	}
+
+	return hg.rs.timer(hg.r.hedgeDelay)
+}
+
+// cleanup dispatches a losing result to the HedgeCleanup callbacks.
+func (hg *hedgeGroup[V]) cleanup(hr hedgeResult[V]) {
+	for _, f := range hg.r.hedgeCleanups {
+		f(hr.result, hr.err)
+	}
+}
+
+// drain cleans up any attempts still in flight.  This method must be
+// called after all the attempts have been canceled.
+func (hg *hedgeGroup[V]) drain() {
+	for ; hg.pending > 0; hg.pending-- {
+		hg.cleanup(<-hg.results)
+	}
+}
+
+// wait blocks until a winning result is available or until every hedged
+// attempt has failed.  In the latter case, the last failure is returned.
+func (hg *hedgeGroup[V]) wait() (last hedgeResult[V]) {
+	hg.launch()
+	for haveLast := false; ; {
+		ch, stop := hg.startTimer()
+		select {
+		case <-ch:
+			hg.launch()
+			continue
+
+		case hr := <-hg.results:
+			stop()
+			hg.pending--
+			if haveLast {
+				hg.cleanup(last)
+			}
+
+			if !hg.r.testRetry(hg.r.newAttempt(hg.rs, hr.result, hr.err)) {
+				return hr
+			}
+
+			last, haveLast = hr, true
+		}
+
+		switch {
+		case hg.canLaunch():
+			hg.launch()
+
+		case hg.pending == 0:
+			return
+		}
+	}
+}
+
+// hedge decorates a task so that each invocation executes a group of hedged attempts.
+func (r *runner[V]) hedge(rs *runState[V], task Task[V]) Task[V] {
+	return func(ctx context.Context) (V, error) {
+		hedgeCtx, cancel := context.WithCancel(ctx)
+		hg := &hedgeGroup[V]{
+			r:       r,
+			rs:      rs,
+			task:    task,
+			ctx:     hedgeCtx,
+			results: make(chan hedgeResult[V], r.hedgeMaxInFlight),
+		}
+
+		winner := hg.wait()
+		cancel()
+		if hg.pending > 0 {
+			go hg.drain()
+		}
+
+		return winner.result, winner.err
+	}
+}
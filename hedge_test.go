@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HedgeSuite struct {
+	CommonSuite
+}
+
+// neverTimer is a Timer that never fires.
+func neverTimer(time.Duration) (<-chan time.Time, func() bool) {
+	return nil, nopStop
+}
+
+// newCleanupChannel creates a HedgeCleanup that sends each loser's error
+// on the returned channel.
+func (suite *HedgeSuite) newCleanupChannel(size int) (HedgeCleanup[int], <-chan error) {
+	ch := make(chan error, size)
+	return func(_ int, err error) { ch <- err }, ch
+}
+
+func (suite *HedgeSuite) receiveCleanup(ch <-chan error) error {
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(time.Second):
+		suite.Fail("no cleanup for a losing hedged attempt")
+		return nil
+	}
+}
+
+func (suite *HedgeSuite) TestDisabled() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      atomic.Int32
+		runner     = suite.newRunner(
+			WithHedging[int](time.Millisecond, 1),
+		)
+	)
+
+	result, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		suite.assertTestCtx(ctx)
+		calls.Add(1)
+		return 123, nil
+	})
+
+	suite.Equal(123, result)
+	suite.NoError(err)
+	suite.Equal(int32(1), calls.Load())
+}
+
+func (suite *HedgeSuite) TestSlowAttemptLoses() {
+	var (
+		testCtx, _  = suite.testCtx()
+		calls       atomic.Int32
+		cleanup, ch = suite.newCleanupChannel(1)
+		runner      = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithHedging[int](time.Second, 2),
+			WithHedgeCleanup(cleanup),
+		)
+	)
+
+	result, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		suite.assertTestCtx(ctx)
+		if calls.Add(1) == 1 {
+			// the first attempt hangs until canceled
+			<-ctx.Done()
+			return -1, ctx.Err()
+		}
+
+		return 123, nil
+	})
+
+	suite.Equal(123, result)
+	suite.NoError(err)
+	suite.ErrorIs(suite.receiveCleanup(ch), context.Canceled)
+	suite.Equal(int32(2), calls.Load())
+}
+
+func (suite *HedgeSuite) TestFailureStartsNextAttempt() {
+	var (
+		testCtx, _  = suite.testCtx()
+		calls       atomic.Int32
+		retryErr    = errors.New("should retry this")
+		cleanup, ch = suite.newCleanupChannel(2)
+		runner      = suite.newRunner(
+			WithTimer[int](neverTimer),
+			WithHedging[int](time.Hour, 3),
+			WithHedgeCleanup(cleanup),
+		)
+	)
+
+	result, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		if calls.Add(1) < 3 {
+			return -1, retryErr
+		}
+
+		return 123, nil
+	})
+
+	suite.Equal(123, result)
+	suite.NoError(err)
+	suite.Equal(int32(3), calls.Load())
+
+	// the first failure is cleaned up when the second failure arrives, and the
+	// second failure is cleaned up when the success arrives
+	suite.ErrorIs(suite.receiveCleanup(ch), retryErr)
+	suite.ErrorIs(suite.receiveCleanup(ch), retryErr)
+}
+
+func (suite *HedgeSuite) TestAllAttemptsFail() {
+	var (
+		testCtx, _  = suite.testCtx()
+		calls       atomic.Int32
+		retryErr    = errors.New("should retry this")
+		cleanup, ch = suite.newCleanupChannel(2)
+		onAttempt   = new(mockOnAttempt[int])
+		runner      = suite.newRunner(
+			WithTimer[int](neverTimer),
+			WithHedging[int](time.Hour, 3),
+			WithHedgeCleanup(cleanup),
+			WithOnAttempt(onAttempt.OnAttempt),
+		)
+	)
+
+	// the group of hedged attempts appears as one attempt
+	onAttempt.ExpectMatch(
+		suite.newTestAttemptMatcher(Attempt[int]{
+			Result: -1,
+			Err:    retryErr,
+		}),
+	).Once()
+
+	result, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		calls.Add(1)
+		return -1, retryErr
+	})
+
+	suite.Equal(-1, result)
+	suite.ErrorIs(err, retryErr)
+	suite.Equal(int32(3), calls.Load())
+	suite.ErrorIs(suite.receiveCleanup(ch), retryErr)
+	suite.ErrorIs(suite.receiveCleanup(ch), retryErr)
+	onAttempt.AssertExpectations(suite.T())
+}
+
+func (suite *HedgeSuite) TestPermanentFailureWins() {
+	var (
+		testCtx, _  = suite.testCtx()
+		calls       atomic.Int32
+		fatalErr    = SetRetryable(errors.New("fatal"), false)
+		cleanup, ch = suite.newCleanupChannel(1)
+		runner      = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithHedging[int](time.Second, 2),
+			WithHedgeCleanup(cleanup),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	result, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			return -1, ctx.Err()
+		}
+
+		return 456, fatalErr
+	})
+
+	suite.Equal(456, result)
+	suite.ErrorIs(err, fatalErr)
+	suite.ErrorIs(suite.receiveCleanup(ch), context.Canceled)
+	suite.Equal(int32(2), calls.Load())
+}
+
+func TestHedge(t *testing.T) {
+	suite.Run(t, new(HedgeSuite))
+}
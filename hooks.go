@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import "context"
+
+// Hooks is a set of callbacks for the lifecycle events of a run.  Any field may be nil.
+// Like OnAttempt, these functions must not panic or block, or task retries will be impacted.
+//
+// Exactly one of OnSuccess, OnGiveUp, or OnCanceled is invoked at the end of each run.
+type Hooks[V any] struct {
+	// OnStart is invoked at the beginning of each run, before the first attempt.
+	// The context is the one passed to Run.
+	OnStart func(ctx context.Context)
+
+	// BeforeAttempt is invoked before each attempt with the context that will be passed
+	// to the task and the number of retries so far.  The returned context, which must
+	// derive from the given context, is passed to the task instead.  This allows the
+	// context to be enriched for each attempt.
+	BeforeAttempt func(ctx context.Context, retries int) context.Context
+
+	// OnRetryScheduled is invoked after an attempt that will be retried, before the
+	// runner waits.  The Attempt's Next field is the interval the runner will wait.
+	OnRetryScheduled func(a Attempt[V])
+
+	// OnSuccess is invoked with the last attempt of a run that stopped with StopSuccess.
+	// The Attempt's Context is the policy context, which is not canceled until all of
+	// the end hooks have returned.
+	OnSuccess func(a Attempt[V])
+
+	// OnGiveUp is invoked when a run stops for any reason other than StopSuccess or
+	// StopCanceled.  The error is the one that Run returns.
+	OnGiveUp func(ctx context.Context, reason StopReason, err error)
+
+	// OnCanceled is invoked when a run stops with StopCanceled, i.e. the context passed
+	// to Run was canceled or reached its deadline.  This includes cancelation while
+	// waiting between retries.  The error is the one that Run returns.
+	OnCanceled func(ctx context.Context, err error)
+}
+
+// WithHooks appends lifecycle Hooks to the created task runner.  This option can be
+// applied repeatedly, and the set of Hooks is cumulative.  Hooks are invoked in the
+// order they were added.
+func WithHooks[V any](h ...Hooks[V]) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.hooks = append(r.hooks, h...)
+		return nil
+	})
+}
+
+// onStart dispatches the OnStart hooks.
+func (r *runner[V]) onStart(rs *runState[V]) {
+	for _, h := range r.hooks {
+		if h.OnStart != nil {
+			h.OnStart(rs.parentCtx)
+		}
+	}
+}
+
+// beforeAttempt dispatches the BeforeAttempt hooks, returning the context for the task.
+func (r *runner[V]) beforeAttempt(rs *runState[V], ctx context.Context) context.Context {
+	for _, h := range r.hooks {
+		if h.BeforeAttempt != nil {
+			ctx = h.BeforeAttempt(ctx, rs.retries)
+		}
+	}
+
+	return ctx
+}
+
+// onRetryScheduled dispatches the OnRetryScheduled hooks.
+func (r *runner[V]) onRetryScheduled(a Attempt[V]) {
+	for _, h := range r.hooks {
+		if h.OnRetryScheduled != nil {
+			h.OnRetryScheduled(a)
+		}
+	}
+}
+
+// onEnd dispatches the OnSuccess, OnGiveUp, or OnCanceled hooks, depending on why the run stopped.
+func (r *runner[V]) onEnd(rs *runState[V], err error) {
+	for _, h := range r.hooks {
+		switch {
+		case rs.reason == StopSuccess && h.OnSuccess != nil:
+			h.OnSuccess(rs.last)
+
+		case rs.reason == StopCanceled && h.OnCanceled != nil:
+			h.OnCanceled(rs.parentCtx, err)
+
+		case rs.reason != StopSuccess && rs.reason != StopCanceled && h.OnGiveUp != nil:
+			h.OnGiveUp(rs.parentCtx, rs.reason, err)
+		}
+	}
+}
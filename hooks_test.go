@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type attemptKey struct{}
+
+type HooksSuite struct {
+	CommonSuite
+
+	retryErr error
+	events   []string
+}
+
+func (suite *HooksSuite) SetupTest() {
+	suite.retryErr = errors.New("should retry this")
+	suite.events = nil
+}
+
+// newHooks creates Hooks that record each event, verifying the context where possible.
+func (suite *HooksSuite) newHooks() Hooks[int] {
+	return Hooks[int]{
+		OnStart: func(ctx context.Context) {
+			suite.assertTestCtx(ctx)
+			suite.events = append(suite.events, "start")
+		},
+		BeforeAttempt: func(ctx context.Context, retries int) context.Context {
+			suite.assertTestCtx(ctx)
+			suite.events = append(suite.events, fmt.Sprintf("before %d", retries))
+			return context.WithValue(ctx, attemptKey{}, retries)
+		},
+		OnRetryScheduled: func(a Attempt[int]) {
+			suite.events = append(suite.events, fmt.Sprintf("retry %d %s", a.Retries, a.Next))
+		},
+		OnSuccess: func(a Attempt[int]) {
+			suite.NoError(a.Context.Err(), "the policy context must not be canceled yet")
+			suite.events = append(suite.events, fmt.Sprintf("success %d", a.Result))
+		},
+		OnGiveUp: func(ctx context.Context, reason StopReason, err error) {
+			suite.assertTestCtx(ctx)
+			suite.events = append(suite.events, fmt.Sprintf("giveUp %s %s", reason, err))
+		},
+		OnCanceled: func(ctx context.Context, err error) {
+			suite.assertTestCtx(ctx)
+			suite.events = append(suite.events, fmt.Sprintf("canceled %s", err))
+		},
+	}
+}
+
+func (suite *HooksSuite) TestSuccess() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      int
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithHooks(suite.newHooks(), Hooks[int]{}),
+			WithPolicyFactory[int](Config{
+				Interval: 5 * time.Second,
+			}),
+		)
+	)
+
+	result, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		suite.Equal(calls, ctx.Value(attemptKey{}), "BeforeAttempt must be able to enrich the context")
+		calls++
+		if calls < 2 {
+			return -1, suite.retryErr
+		}
+
+		return 123, nil
+	})
+
+	suite.NoError(err)
+	suite.Equal(123, result)
+	suite.Equal(
+		[]string{"start", "before 0", "retry 0 5s", "before 1", "success 123"},
+		suite.events,
+	)
+}
+
+func (suite *HooksSuite) TestGiveUp() {
+	var (
+		testCtx, _ = suite.testCtx()
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithHooks(suite.newHooks()),
+			WithPolicyFactory[int](Config{
+				Interval:   time.Second,
+				MaxRetries: 1,
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return -1, suite.retryErr
+	})
+
+	suite.ErrorIs(err, suite.retryErr)
+	suite.Equal(
+		[]string{"start", "before 0", "retry 0 1s", "before 1", "giveUp exhausted should retry this"},
+		suite.events,
+	)
+}
+
+func (suite *HooksSuite) TestCanceledWhileWaiting() {
+	var (
+		testCtx, cancel = suite.testCtx()
+		hooks           = suite.newHooks()
+		onRetry         = hooks.OnRetryScheduled
+	)
+
+	hooks.OnRetryScheduled = func(a Attempt[int]) {
+		onRetry(a)
+		cancel()
+	}
+
+	runner := suite.newRunner(
+		WithTimer[int](func(time.Duration) (<-chan time.Time, func() bool) {
+			return nil, nopStop // never fires
+		}),
+		WithHooks(hooks),
+		WithPolicyFactory[int](Config{
+			Interval: time.Minute,
+		}),
+	)
+
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return -1, suite.retryErr
+	})
+
+	suite.ErrorIs(err, context.Canceled)
+	suite.Equal(
+		[]string{"start", "before 0", "retry 0 1m0s", "canceled context canceled"},
+		suite.events,
+	)
+}
+
+func (suite *HooksSuite) TestCanceledDuringAttemptWithClassifier() {
+	var (
+		testCtx, cancel = suite.testCtx()
+		runner          = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithClassifier[int](nil),
+			WithHooks(suite.newHooks()),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		cancel()
+		return -1, ctx.Err()
+	})
+
+	suite.ErrorIs(err, context.Canceled)
+	suite.Equal(
+		[]string{"start", "before 0", "canceled context canceled"},
+		suite.events,
+		"a caller cancel must be reported as such, even though the Classifier won't retry it",
+	)
+}
+
+func (suite *HooksSuite) TestCircuitOpen() {
+	var (
+		testCtx, _ = suite.testCtx()
+		cb         = NewCircuitBreaker(BreakerConfig{ConsecutiveFailures: 1})
+		runner     = suite.newRunner(
+			WithHooks(suite.newHooks()),
+			WithCircuitBreaker[int](cb),
+		)
+	)
+
+	cb.record(0, true)
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		suite.Fail("the task should not be called")
+		return 0, nil
+	})
+
+	suite.ErrorIs(err, ErrCircuitOpen)
+	suite.Equal(
+		[]string{"start", "giveUp circuitOpen " + ErrCircuitOpen.Error()},
+		suite.events,
+	)
+}
+
+func TestHooks(t *testing.T) {
+	suite.Run(t, new(HooksSuite))
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// RetryDecision is the runner's plan for a retry, which an Interceptor may adjust.
+type RetryDecision struct {
+	// Next is the interval the runner will wait before the retry.  Initially, this is
+	// the interval returned by the policy.  If an Interceptor sets this to a nonpositive
+	// value, the run is aborted.
+	Next time.Duration
+
+	// Err is the error that Run returns if the run is aborted.  Initially, this is the
+	// attempt's error.  It is ignored if the retry proceeds.  If an Interceptor aborts
+	// the run with a nil Err, the attempt's error is kept, so that a failed run is never
+	// reported as a success.
+	Err error
+
+	// Abort halts the run instead of retrying.
+	Abort bool
+}
+
+// Interceptor examines a retry after the ShouldRetry strategy and the policy have allowed
+// it, but before the runner waits.  An Interceptor may abort the run, change the interval,
+// or substitute the error that Run returns.  The Attempt's Next field is always zero, since
+// the decision holds the interval.
+//
+// Unlike OnAttempt, an Interceptor influences control flow.  It must not block.
+type Interceptor[V any] func(a Attempt[V], d RetryDecision) RetryDecision
+
+// WithInterceptors appends Interceptors to the created task runner.  This option can be
+// applied repeatedly, and the set of Interceptors is cumulative.  Interceptors are applied
+// in order, each receiving the decision made by the previous one.  Once the run is aborted,
+// no further Interceptors are applied.
+//
+// A run aborted by an Interceptor stops with StopIntercepted.  The Attempt passed to any
+// OnAttempt callbacks has the interval and error decided by the Interceptors.
+func WithInterceptors[V any](i ...Interceptor[V]) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.interceptors = append(r.interceptors, i...)
+		return nil
+	})
+}
+
+// CapInterval returns an Interceptor that limits the interval before each retry to limit.
+func CapInterval[V any](limit time.Duration) Interceptor[V] {
+	return func(_ Attempt[V], d RetryDecision) RetryDecision {
+		d.Next = min(d.Next, limit)
+		return d
+	}
+}
+
+// AbortOnDone returns an Interceptor that aborts the run once the given context is done,
+// e.g. a context that is canceled when the application starts shutting down.  The
+// attempt's error is still returned from Run.
+func AbortOnDone[V any](ctx context.Context) Interceptor[V] {
+	return func(_ Attempt[V], d RetryDecision) RetryDecision {
+		d.Abort = d.Abort || ctx.Err() != nil
+		return d
+	}
+}
+
+// intercept applies the Interceptors to a retry that the policy has allowed.  If the run
+// is aborted, this method returns false and replaces the Attempt's error with any error
+// the Interceptors substituted, recategorizing it so that the Category describes the error
+// Run returns.
+func (r *runner[V]) intercept(rs *runState[V], a *Attempt[V], interval time.Duration) (time.Duration, bool) {
+	d := RetryDecision{
+		Next: interval,
+		Err:  a.Err,
+	}
+
+	for _, f := range r.interceptors {
+		if d = f(*a, d); d.Abort || d.Next <= 0 {
+			if d.Err != nil {
+				a.Err = d.Err
+				a.Category = r.classifier.Categorize(d.Err)
+			}
+
+			rs.reason = StopIntercepted
+			return 0, false
+		}
+	}
+
+	return d.Next, true
+}
@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InterceptorSuite struct {
+	CommonSuite
+
+	retryErr error
+}
+
+func (suite *InterceptorSuite) SetupTest() {
+	suite.retryErr = errors.New("should retry this")
+}
+
+// failingTask returns a task that always fails with the retryable error, counting its calls.
+func (suite *InterceptorSuite) failingTask(calls *int) Task[int] {
+	return func(context.Context) (int, error) {
+		*calls++
+		return -1, suite.retryErr
+	}
+}
+
+func (suite *InterceptorSuite) TestAbort() {
+	var (
+		testCtx, _  = suite.testCtx()
+		abortErr    = errors.New("aborted")
+		calls       int
+		last        Attempt[int]
+		reason      StopReason
+		budget      = NewBudget(BudgetConfig{})
+		startTokens = budget.Tokens()
+		runner      = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithBudget[int](budget),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+			WithInterceptors(
+				func(a Attempt[int], d RetryDecision) RetryDecision {
+					suite.ErrorIs(d.Err, suite.retryErr)
+					suite.Equal(time.Second, d.Next)
+					suite.Zero(a.Next)
+					if a.Retries == 1 {
+						d.Abort = true
+						d.Err = abortErr
+					}
+
+					return d
+				},
+				func(a Attempt[int], d RetryDecision) RetryDecision {
+					suite.Less(a.Retries, 1, "interceptors after an abort must not be applied")
+					return d
+				},
+			),
+			WithOnAttempt(func(a Attempt[int]) {
+				last = a
+			}),
+			WithHooks(Hooks[int]{
+				OnGiveUp: func(_ context.Context, r StopReason, err error) {
+					reason = r
+					suite.Same(abortErr, err)
+				},
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, suite.failingTask(&calls))
+	suite.Same(abortErr, err)
+	suite.Equal(2, calls)
+	suite.Equal(StopIntercepted, reason)
+	suite.True(last.Done())
+	suite.Same(abortErr, last.Err)
+	suite.Equal(CategoryUnknown, last.Category, "the category must describe the substituted error")
+	suite.Equal(startTokens-DefaultBudgetRetryCost, budget.Tokens(), "an aborted retry must not spend the budget")
+}
+
+func (suite *InterceptorSuite) TestAbortRecategorizes() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      int
+		last       Attempt[int]
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+			WithInterceptors(func(_ Attempt[int], d RetryDecision) RetryDecision {
+				d.Abort = true
+				d.Err = SetCategory(d.Err, CategoryThrottled)
+				return d
+			}),
+			WithOnAttempt(func(a Attempt[int]) {
+				last = a
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, suite.failingTask(&calls))
+	suite.ErrorIs(err, suite.retryErr)
+	suite.Equal(1, calls)
+	suite.Equal(err, last.Err)
+	suite.Equal(CategoryThrottled, last.Category)
+}
+
+func (suite *InterceptorSuite) TestAbortWithoutErr() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      int
+		last       Attempt[int]
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+			WithInterceptors(func(Attempt[int], RetryDecision) RetryDecision {
+				return RetryDecision{Abort: true}
+			}),
+			WithOnAttempt(func(a Attempt[int]) {
+				last = a
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, suite.failingTask(&calls))
+	suite.ErrorIs(err, suite.retryErr, "a nil Err must not turn a failure into a success")
+	suite.Equal(1, calls)
+	suite.Same(suite.retryErr, last.Err)
+	suite.Equal(CategoryUnknown, last.Category)
+}
+
+func (suite *InterceptorSuite) TestNonpositiveNext() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      int
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+			WithInterceptors(func(_ Attempt[int], d RetryDecision) RetryDecision {
+				d.Next = 0
+				return d
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, suite.failingTask(&calls))
+	suite.ErrorIs(err, suite.retryErr)
+	suite.Equal(1, calls)
+}
+
+func (suite *InterceptorSuite) TestAdjustInterval() {
+	var (
+		testCtx, _ = suite.testCtx()
+		calls      int
+		waits      []time.Duration
+		nexts      []time.Duration
+		runner     = suite.newRunner(
+			WithTimer[int](func(d time.Duration) (<-chan time.Time, func() bool) {
+				waits = append(waits, d)
+				return immediateTimer(d)
+			}),
+			WithPolicyFactory[int](Config{
+				Interval:   10 * time.Second,
+				MaxRetries: 2,
+			}),
+			WithInterceptors(
+				func(_ Attempt[int], d RetryDecision) RetryDecision {
+					d.Next *= 2
+					return d
+				},
+				CapInterval[int](15*time.Second),
+			),
+			WithOnAttempt(func(a Attempt[int]) {
+				nexts = append(nexts, a.Next)
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, suite.failingTask(&calls))
+	suite.ErrorIs(err, suite.retryErr)
+	suite.Equal(3, calls)
+	suite.Equal([]time.Duration{15 * time.Second, 15 * time.Second}, waits)
+	suite.Equal([]time.Duration{15 * time.Second, 15 * time.Second, 0}, nexts)
+}
+
+func (suite *InterceptorSuite) TestAbortOnDone() {
+	var (
+		testCtx, _            = suite.testCtx()
+		shutdownCtx, shutdown = context.WithCancel(context.Background())
+		calls                 int
+		reason                StopReason
+		runner                = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+			WithInterceptors(AbortOnDone[int](shutdownCtx)),
+			WithHooks(Hooks[int]{
+				OnGiveUp: func(_ context.Context, r StopReason, _ error) {
+					reason = r
+				},
+			}),
+		)
+	)
+
+	defer shutdown()
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		calls++
+		if calls == 3 {
+			shutdown()
+		}
+
+		return -1, suite.retryErr
+	})
+
+	suite.ErrorIs(err, suite.retryErr)
+	suite.Equal(3, calls)
+	suite.Equal(StopIntercepted, reason)
+}
+
+func TestInterceptor(t *testing.T) {
+	suite.Run(t, new(InterceptorSuite))
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// LoopAttempt describes a single iteration of a loop over Attempts.
+type LoopAttempt struct {
+	// Context is the policy context, which enforces the policy's limits such as
+	// Config.MaxElapsedTime.  The work done in the loop body should use this context.
+	// It is canceled once the loop ends.
+	Context context.Context
+
+	// Retries is the number of retries before this iteration.  It is zero for the
+	// initial attempt.
+	Retries int
+
+	// Elapsed is the time since the loop started, as measured by the Clock returned
+	// by ClockFromContext.
+	Elapsed time.Duration
+}
+
+// Attempts returns an iterator for a hand-written retry loop.  This is an alternative
+// to a Runner for logic that doesn't fit in a Task, such as a multistep state machine
+// or code that must return several values:
+//
+//	err := ctx.Err() // reported if the loop body never executes
+//	for a := range retry.Attempts(ctx, cfg) {
+//		if err = step(a.Context); err == nil {
+//			break // success, so stop retrying
+//		}
+//	}
+//
+// Like Runner.Run, the loop body does not execute at all if ctx is already canceled or
+// past its deadline.  Otherwise, it executes at least once.  When the body continues, the
+// iterator consults the policy created by pf and waits out the retry interval before the
+// next iteration.  The loop ends when the policy is exhausted or when ctx is canceled, in
+// which case the last error the body observed should be reported.  To stop retrying
+// early, e.g. for an error that isn't retryable, break or return from the loop.
+//
+// If pf is nil, the loop body executes at most once.  Waits use the Clock returned by
+// ClockFromContext, and the returned iterator may be used more than once.
+func Attempts(ctx context.Context, pf PolicyFactory) iter.Seq[LoopAttempt] {
+	if pf == nil {
+		pf = Config{}
+	}
+
+	return func(yield func(LoopAttempt) bool) {
+		var (
+			clock  = ClockFromContext(ctx)
+			start  = clock.Now()
+			policy = pf.NewPolicy(ctx)
+		)
+
+		defer policy.Cancel()
+		for retries := 0; ; retries++ {
+			// don't start an iteration once the policy context is done, even the first one
+			if clockContextErr(policy.Context(), clock) != nil {
+				return
+			}
+
+			a := LoopAttempt{
+				Context: policy.Context(),
+				Retries: retries,
+				Elapsed: clock.Now().Sub(start),
+			}
+
+			if !yield(a) || !awaitLoopRetry(policy, clock) {
+				return
+			}
+		}
+	}
+}
+
+// awaitLoopRetry obtains the next interval from the policy and waits it out.  This
+// function returns false if the loop should end instead.
+func awaitLoopRetry(policy Policy, clock Clock) bool {
+	interval, ok := policy.Next()
+	if !ok {
+		return false
+	}
+
+	ch, stop := clock.NewTimer(interval)
+	select {
+	case <-policy.Context().Done():
+		stop()
+		return false
+
+	case <-ch:
+		return clockContextErr(policy.Context(), clock) == nil
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoopSuite struct {
+	CommonSuite
+}
+
+// clockCtx returns a test context that carries a Clock whose timers fire immediately.
+func (suite *LoopSuite) clockCtx() (context.Context, context.CancelFunc) {
+	testCtx, cancel := suite.testCtx()
+	return ContextWithClock(testCtx, &stoppedClock{now: time.Now()}), cancel
+}
+
+func (suite *LoopSuite) TestExhausted() {
+	var (
+		ctx, _  = suite.clockCtx()
+		retries []int
+	)
+
+	for a := range Attempts(ctx, Config{Interval: time.Hour, MaxRetries: 3}) {
+		suite.NoError(a.Context.Err())
+		suite.Zero(a.Elapsed)
+		retries = append(retries, a.Retries)
+	}
+
+	suite.Equal([]int{0, 1, 2, 3}, retries)
+}
+
+func (suite *LoopSuite) TestBreak() {
+	var (
+		ctx, _     = suite.clockCtx()
+		iterations int
+		policyCtx  context.Context
+	)
+
+	for a := range Attempts(ctx, Config{Interval: time.Hour}) {
+		iterations++
+		policyCtx = a.Context
+		if a.Retries == 2 {
+			break
+		}
+	}
+
+	suite.Equal(3, iterations)
+	suite.Require().NotNil(policyCtx)
+	suite.Error(policyCtx.Err(), "the policy context must be canceled when the loop ends")
+}
+
+func (suite *LoopSuite) TestNilPolicyFactory() {
+	var (
+		ctx, _     = suite.clockCtx()
+		iterations int
+	)
+
+	for range Attempts(ctx, nil) {
+		iterations++
+	}
+
+	suite.Equal(1, iterations)
+}
+
+func (suite *LoopSuite) TestCanceled() {
+	var (
+		ctx, cancel = suite.testCtx()
+		iterations  int
+	)
+
+	// the default Clock is used, so the loop must end when ctx is canceled while waiting
+	for a := range Attempts(ctx, Config{Interval: time.Hour}) {
+		iterations++
+		suite.Zero(a.Retries)
+		cancel()
+	}
+
+	suite.Equal(1, iterations)
+	suite.ErrorIs(ctx.Err(), context.Canceled)
+}
+
+func (suite *LoopSuite) TestCanceledBeforeStart() {
+	var (
+		ctx, cancel = suite.clockCtx()
+		iterations  int
+	)
+
+	cancel()
+	for range Attempts(ctx, Config{Interval: time.Hour}) {
+		iterations++
+	}
+
+	suite.Zero(iterations, "the loop body must not execute once ctx is done, just like Runner.Run")
+}
+
+func (suite *LoopSuite) TestDeadlinePassedBeforeStart() {
+	var (
+		ctx, _     = suite.clockCtx()
+		iterations int
+	)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	for range Attempts(ctx, nil) {
+		iterations++
+	}
+
+	suite.Zero(iterations)
+}
+
+func (suite *LoopSuite) TestReuse() {
+	var (
+		ctx, _     = suite.clockCtx()
+		seq        = Attempts(ctx, Config{Interval: time.Hour, MaxRetries: 1})
+		iterations int
+	)
+
+	for range 2 {
+		for range seq {
+			iterations++
+		}
+	}
+
+	suite.Equal(4, iterations)
+}
+
+func TestLoop(t *testing.T) {
+	suite.Run(t, new(LoopSuite))
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import "time"
+
+// The names of the metrics recorded by a Runner configured with WithMetrics.
+const (
+	// MetricRuns is a counter of calls to Run or RunAsync.
+	MetricRuns = "retry_runs_total"
+
+	// MetricAttempts is a counter of task attempts, including the initial attempt.
+	MetricAttempts = "retry_attempts_total"
+
+	// MetricRetries is a counter of retries, i.e. attempts other than the initial attempt.
+	MetricRetries = "retry_retries_total"
+
+	// MetricSuccesses is a counter of runs that stopped with StopSuccess.
+	MetricSuccesses = "retry_successes_total"
+
+	// MetricGiveUps is a counter of runs that stopped for any other reason.  It has
+	// the LabelReason label.
+	MetricGiveUps = "retry_give_ups_total"
+
+	// MetricAttemptsPerRun is a histogram of the number of attempts in each run.
+	MetricAttemptsPerRun = "retry_attempts_per_run"
+
+	// MetricSleepSeconds is a histogram of the intervals, in seconds, waited before each retry.
+	MetricSleepSeconds = "retry_sleep_seconds"
+
+	// MetricRunSeconds is a histogram of the total duration, in seconds, of each run.
+	MetricRunSeconds = "retry_run_duration_seconds"
+)
+
+// The names of the labels attached to metrics.
+const (
+	// LabelRunner is the name given to WithMetrics.  Every metric has this label.
+	LabelRunner = "runner"
+
+	// LabelReason is the StopReason for MetricGiveUps.
+	LabelReason = "reason"
+)
+
+// Counter is a metric that only increases.
+type Counter interface {
+	// Add increases this counter by a nonnegative delta.
+	Add(delta float64)
+}
+
+// CounterFunc is a function type that implements Counter.
+type CounterFunc func(float64)
+
+func (cf CounterFunc) Add(delta float64) { cf(delta) }
+
+// Histogram is a metric that tracks the distribution of observed values.
+type Histogram interface {
+	// Observe records a value.
+	Observe(value float64)
+}
+
+// HistogramFunc is a function type that implements Histogram.
+type HistogramFunc func(float64)
+
+func (hf HistogramFunc) Observe(value float64) { hf(value) }
+
+// Label is a name/value pair that identifies a metric.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Metrics is the backend for the metrics recorded by runners.  Implementations must be
+// safe for concurrent use.  The retryexpvar package provides a backend that publishes
+// through the stdlib expvar package.
+//
+// This interface is also the adapter point for other metrics libraries.  For example, a
+// Prometheus backend can create or look up a vector for each name and then use the
+// CounterFunc and HistogramFunc types:
+//
+//	func (pm promMetrics) Counter(name string, labels ...retry.Label) retry.Counter {
+//		return retry.CounterFunc(pm.counterVec(name, labels).With(toPromLabels(labels)).Add)
+//	}
+type Metrics interface {
+	// Counter returns the counter with the given name and labels.
+	Counter(name string, labels ...Label) Counter
+
+	// Histogram returns the histogram with the given name and labels.
+	Histogram(name string, labels ...Label) Histogram
+}
+
+// runnerMetrics holds the metrics for one Runner.  A nil *runnerMetrics records nothing.
+type runnerMetrics struct {
+	runs           Counter
+	attempts       Counter
+	retries        Counter
+	successes      Counter
+	giveUps        map[StopReason]Counter
+	attemptsPerRun Histogram
+	sleep          Histogram
+	runDuration    Histogram
+}
+
+func newRunnerMetrics(m Metrics, runnerName string) *runnerMetrics {
+	runner := Label{Name: LabelRunner, Value: runnerName}
+	rm := &runnerMetrics{
+		runs:           m.Counter(MetricRuns, runner),
+		attempts:       m.Counter(MetricAttempts, runner),
+		retries:        m.Counter(MetricRetries, runner),
+		successes:      m.Counter(MetricSuccesses, runner),
+		giveUps:        make(map[StopReason]Counter, len(stopReasons)),
+		attemptsPerRun: m.Histogram(MetricAttemptsPerRun, runner),
+		sleep:          m.Histogram(MetricSleepSeconds, runner),
+		runDuration:    m.Histogram(MetricRunSeconds, runner),
+	}
+
+	for _, sr := range stopReasons {
+		if sr != StopSuccess {
+			rm.giveUps[sr] = m.Counter(MetricGiveUps, runner, Label{Name: LabelReason, Value: sr.String()})
+		}
+	}
+
+	return rm
+}
+
+func (rm *runnerMetrics) runStarted() {
+	if rm != nil {
+		rm.runs.Add(1)
+	}
+}
+
+func (rm *runnerMetrics) attempted() {
+	if rm != nil {
+		rm.attempts.Add(1)
+	}
+}
+
+func (rm *runnerMetrics) retrying(interval time.Duration) {
+	if rm != nil {
+		rm.retries.Add(1)
+		rm.sleep.Observe(interval.Seconds())
+	}
+}
+
+func (rm *runnerMetrics) runEnded(reason StopReason, attempts int, duration time.Duration) {
+	if rm == nil {
+		return
+	}
+
+	if reason == StopSuccess {
+		rm.successes.Add(1)
+	} else {
+		rm.giveUps[reason].Add(1)
+	}
+
+	rm.attemptsPerRun.Observe(float64(attempts))
+	rm.runDuration.Observe(duration.Seconds())
+}
+
+// WithMetrics records metrics about the created task runner's runs, attempts, and retries
+// using the given backend.  Each metric has the LabelRunner label with the given name.
+// The MetricRetries counter divided by the MetricRuns counter is the retry amplification.
+//
+// If m is nil, no metrics are recorded.
+func WithMetrics[V any](m Metrics, runnerName string) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.metrics = nil
+		if m != nil {
+			r.metrics = newRunnerMetrics(m, runnerName)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// testMetrics is an in-memory Metrics backend.  Counters and histograms are keyed by
+// the metric name and the label values.
+type testMetrics struct {
+	lock         sync.Mutex
+	counters     map[string]float64
+	observations map[string][]float64
+}
+
+func newTestMetrics() *testMetrics {
+	return &testMetrics{
+		counters:     make(map[string]float64),
+		observations: make(map[string][]float64),
+	}
+}
+
+func testMetricKey(name string, labels []Label) string {
+	for _, l := range labels {
+		name += "/" + l.Value
+	}
+
+	return name
+}
+
+func (tm *testMetrics) Counter(name string, labels ...Label) Counter {
+	key := testMetricKey(name, labels)
+	return CounterFunc(func(delta float64) {
+		defer tm.lock.Unlock()
+		tm.lock.Lock()
+		tm.counters[key] += delta
+	})
+}
+
+func (tm *testMetrics) Histogram(name string, labels ...Label) Histogram {
+	key := testMetricKey(name, labels)
+	return HistogramFunc(func(value float64) {
+		defer tm.lock.Unlock()
+		tm.lock.Lock()
+		tm.observations[key] = append(tm.observations[key], value)
+	})
+}
+
+type MetricsSuite struct {
+	CommonSuite
+
+	metrics  *testMetrics
+	retryErr error
+}
+
+func (suite *MetricsSuite) SetupTest() {
+	suite.metrics = newTestMetrics()
+	suite.retryErr = errors.New("should retry this")
+}
+
+func (suite *MetricsSuite) newMetricsRunner(o ...RunnerOption[int]) Runner[int] {
+	return suite.newRunner(
+		append(
+			[]RunnerOption[int]{
+				WithImmediateTimer[int](),
+				WithMetrics[int](suite.metrics, "test"),
+			},
+			o...,
+		)...,
+	)
+}
+
+func (suite *MetricsSuite) assertGiveUp(reason StopReason) {
+	suite.Equal(1.0, suite.metrics.counters[MetricGiveUps+"/test/"+reason.String()])
+	suite.Zero(suite.metrics.counters[MetricSuccesses+"/test"])
+}
+
+func (suite *MetricsSuite) TestSuccessAfterRetries() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		runner     = suite.newMetricsRunner(
+			WithPolicyFactory[int](Config{
+				Interval: 5 * time.Second,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, suite.retryErr).Twice()
+	task.ExpectMatch(suite.assertTestCtx, 123, nil).Once()
+	_, err := runner.Run(testCtx, task.Do)
+	suite.Require().NoError(err)
+
+	suite.Equal(
+		map[string]float64{
+			MetricRuns + "/test":      1.0,
+			MetricAttempts + "/test":  3.0,
+			MetricRetries + "/test":   2.0,
+			MetricSuccesses + "/test": 1.0,
+		},
+		suite.metrics.counters,
+	)
+
+	suite.Equal([]float64{3.0}, suite.metrics.observations[MetricAttemptsPerRun+"/test"])
+	suite.Equal([]float64{5.0, 5.0}, suite.metrics.observations[MetricSleepSeconds+"/test"])
+	suite.Len(suite.metrics.observations[MetricRunSeconds+"/test"], 1)
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *MetricsSuite) TestPermanent() {
+	testCtx, _ := suite.testCtx()
+	_, err := suite.newMetricsRunner().Run(testCtx, func(context.Context) (int, error) {
+		return -1, SetRetryable(suite.retryErr, false)
+	})
+
+	suite.ErrorIs(err, suite.retryErr)
+	suite.assertGiveUp(StopPermanent)
+}
+
+func (suite *MetricsSuite) TestExhausted() {
+	testCtx, _ := suite.testCtx()
+	runner := suite.newMetricsRunner(
+		WithPolicyFactory[int](Config{
+			Interval:   time.Second,
+			MaxRetries: 1,
+		}),
+	)
+
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return -1, suite.retryErr
+	})
+
+	suite.ErrorIs(err, suite.retryErr)
+	suite.assertGiveUp(StopExhausted)
+	suite.Equal(1.0, suite.metrics.counters[MetricRetries+"/test"])
+}
+
+func (suite *MetricsSuite) TestBudget() {
+	testCtx, _ := suite.testCtx()
+	runner := suite.newMetricsRunner(
+		WithBudget[int](NewBudget(BudgetConfig{MaxTokens: 0.5})),
+		WithPolicyFactory[int](Config{
+			Interval: time.Second,
+		}),
+	)
+
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return -1, suite.retryErr
+	})
+
+	suite.ErrorIs(err, suite.retryErr)
+	suite.assertGiveUp(StopBudget)
+}
+
+func (suite *MetricsSuite) TestCircuitOpen() {
+	var (
+		testCtx, _ = suite.testCtx()
+		cb         = NewCircuitBreaker(BreakerConfig{ConsecutiveFailures: 1})
+		runner     = suite.newMetricsRunner(WithCircuitBreaker[int](cb))
+	)
+
+	cb.record(0, true)
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		suite.Fail("the task should not be called")
+		return 0, nil
+	})
+
+	suite.ErrorIs(err, ErrCircuitOpen)
+	suite.assertGiveUp(StopCircuitOpen)
+	suite.Equal([]float64{0.0}, suite.metrics.observations[MetricAttemptsPerRun+"/test"])
+}
+
+func (suite *MetricsSuite) TestCanceled() {
+	var (
+		testCtx, cancel = suite.testCtx()
+		runner          = suite.newMetricsRunner(
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		cancel()
+		return -1, suite.retryErr
+	})
+
+	// the policy halts retries, so the last error is returned
+	suite.ErrorIs(err, suite.retryErr)
+	suite.assertGiveUp(StopCanceled)
+}
+
+func (suite *MetricsSuite) TestCanceledWithClassifier() {
+	var (
+		testCtx, cancel = suite.testCtx()
+		runner          = suite.newMetricsRunner(
+			WithClassifier[int](nil),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	_, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		cancel()
+		return -1, ctx.Err()
+	})
+
+	// the Classifier won't retry the context error, but the caller gave up first
+	suite.ErrorIs(err, context.Canceled)
+	suite.assertGiveUp(StopCanceled)
+	suite.Zero(suite.metrics.counters[MetricGiveUps+"/test/"+StopPermanent.String()])
+}
+
+func (suite *MetricsSuite) TestMaxElapsedTime() {
+	testCtx, _ := suite.testCtx()
+	runner := suite.newMetricsRunner(
+		WithTimer[int](func(time.Duration) (<-chan time.Time, func() bool) {
+			return nil, nopStop // never fires, so the policy deadline is reached
+		}),
+		WithPolicyFactory[int](Config{
+			Interval:       time.Hour,
+			MaxElapsedTime: 10 * time.Millisecond,
+		}),
+	)
+
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return -1, suite.retryErr
+	})
+
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.assertGiveUp(StopExhausted)
+}
+
+func (suite *MetricsSuite) TestNilMetrics() {
+	testCtx, _ := suite.testCtx()
+	runner := suite.newRunner(WithMetrics[int](nil, "test"))
+	result, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		return 123, nil
+	})
+
+	suite.NoError(err)
+	suite.Equal(123, result)
+}
+
+func TestMetrics(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultRegistryName is the name of the Registry entry from which all other entries
+// inherit their configuration.
+const DefaultRegistryName = "default"
+
+// ErrNotRegistered indicates that a Registry had no entry with a requested name.
+var ErrNotRegistered = errors.New("no retry configuration registered with that name")
+
+// Registry is a set of named Configs, typically one for each retried call site in an
+// application.  This type is friendly to being unmarshaled from a single external
+// document, e.g.:
+//
+//	retry:
+//	  policies:
+//	    default:
+//	      interval: 1s
+//	      multiplier: 2.0
+//	      maxRetries: 5
+//	    downstreamA:
+//	      maxElapsedTime: 30s
+//	    downstreamB:
+//	      interval: 250ms
+//	      maxRetries: -1
+//
+// The DefaultRegistryName entry, if present, supplies the value of each field that is
+// unset, i.e. zero, in another entry.  Since the nonpositive values of each Config field
+// have the same meaning as zero, use a negative value to override a default with that
+// meaning, e.g. a MaxRetries of -1 for unlimited retries.
+//
+// As with Config, encoding/json decodes durations as integer nanoseconds, while
+// gopkg.in/yaml.v3 also accepts strings such as "30s".
+//
+// A Registry must not be modified while it is used concurrently.
+type Registry map[string]Config
+
+// Config returns the configuration registered with the given name, with any unset fields
+// inherited from the DefaultRegistryName entry.  If there is no entry with the given name,
+// this method returns false.  Unknown names do not fall back to the DefaultRegistryName
+// entry, so that a misspelled name is reported rather than silently using the defaults.
+// To use the defaults, request DefaultRegistryName explicitly.
+func (r Registry) Config(name string) (Config, bool) {
+	c, ok := r[name]
+	if !ok {
+		return Config{}, false
+	}
+
+	if def, hasDefault := r[DefaultRegistryName]; hasDefault {
+		c = c.inherit(def)
+	}
+
+	return c, true
+}
+
+// PolicyFactory returns a PolicyFactory for the given name, as described in Config.
+// If there is no configuration for the name, the returned error wraps ErrNotRegistered.
+func (r Registry) PolicyFactory(name string) (PolicyFactory, error) {
+	c, ok := r.Config(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotRegistered, name)
+	}
+
+	return c, nil
+}
+
+// NewRegistryRunner creates a Runner whose PolicyFactory is obtained from the Registry
+// with the given name.  Any options are applied after the registered PolicyFactory, so
+// they may override it.
+func NewRegistryRunner[V any](r Registry, name string, opts ...RunnerOption[V]) (Runner[V], error) {
+	pf, err := r.PolicyFactory(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRunner(
+		append([]RunnerOption[V]{WithPolicyFactory[V](pf)}, opts...)...,
+	)
+}
+
+// inherit returns a copy of this Config with each unset field taken from def.
+func (c Config) inherit(def Config) Config {
+	if c.Interval == 0 {
+		c.Interval = def.Interval
+	}
+
+	if c.Jitter == 0.0 {
+		c.Jitter = def.Jitter
+	}
+
+	if c.Multiplier == 0.0 {
+		c.Multiplier = def.Multiplier
+	}
+
+	if c.MaxRetries == 0 {
+		c.MaxRetries = def.MaxRetries
+	}
+
+	if c.MaxElapsedTime == 0 {
+		c.MaxElapsedTime = def.MaxElapsedTime
+	}
+
+	if c.MaxInterval == 0 {
+		c.MaxInterval = def.MaxInterval
+	}
+
+	return c
+}
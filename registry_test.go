@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+)
+
+type RegistrySuite struct {
+	CommonSuite
+}
+
+func (suite *RegistrySuite) TestUnmarshalYAML() {
+	var doc struct {
+		Retry struct {
+			Policies Registry `yaml:"policies"`
+		} `yaml:"retry"`
+	}
+
+	suite.Require().NoError(yaml.Unmarshal(
+		[]byte(`
+retry:
+  policies:
+    default:
+      interval: 1s
+      multiplier: 2.0
+      maxRetries: 5
+    downstreamA:
+      maxElapsedTime: 30s
+    downstreamB:
+      interval: 250ms
+      maxRetries: -1
+`),
+		&doc,
+	))
+
+	r := doc.Retry.Policies
+	c, ok := r.Config("downstreamA")
+	suite.True(ok)
+	suite.Equal(
+		Config{
+			Interval:       time.Second,
+			Multiplier:     2.0,
+			MaxRetries:     5,
+			MaxElapsedTime: 30 * time.Second,
+		},
+		c,
+	)
+
+	c, ok = r.Config("downstreamB")
+	suite.True(ok)
+	suite.Equal(
+		Config{
+			Interval:   250 * time.Millisecond,
+			Multiplier: 2.0,
+			MaxRetries: -1,
+		},
+		c,
+	)
+
+	c, ok = r.Config(DefaultRegistryName)
+	suite.True(ok)
+	suite.Equal(r[DefaultRegistryName], c)
+
+	_, ok = r.Config("unconfigured")
+	suite.False(ok, "unknown names must not fall back to the default entry")
+
+	_, err := r.PolicyFactory("unconfigured")
+	suite.ErrorIs(err, ErrNotRegistered)
+}
+
+func (suite *RegistrySuite) TestUnmarshalJSON() {
+	var r Registry
+	suite.Require().NoError(json.Unmarshal(
+		[]byte(`{"default": {"maxRetries": 3}, "downstreamA": {"interval": 1000000000}}`),
+		&r,
+	))
+
+	c, ok := r.Config("downstreamA")
+	suite.True(ok)
+	suite.Equal(Config{Interval: time.Second, MaxRetries: 3}, c)
+}
+
+func (suite *RegistrySuite) TestNoDefault() {
+	r := Registry{
+		"downstreamA": {Interval: time.Second},
+	}
+
+	c, ok := r.Config("downstreamA")
+	suite.True(ok)
+	suite.Equal(Config{Interval: time.Second}, c)
+
+	_, ok = r.Config("unconfigured")
+	suite.False(ok)
+
+	pf, err := r.PolicyFactory("unconfigured")
+	suite.Nil(pf)
+	suite.ErrorIs(err, ErrNotRegistered)
+	suite.ErrorContains(err, `"unconfigured"`)
+
+	runner, err := NewRegistryRunner[int](r, "unconfigured")
+	suite.Nil(runner)
+	suite.ErrorIs(err, ErrNotRegistered)
+}
+
+func (suite *RegistrySuite) TestNewRegistryRunner() {
+	var (
+		testCtx, _ = suite.testCtx()
+		retryErr   = errors.New("should retry this")
+		calls      int
+		r          = Registry{
+			DefaultRegistryName: {Interval: time.Second},
+			"downstreamA":       {MaxRetries: 2},
+		}
+	)
+
+	runner, err := NewRegistryRunner(r, "downstreamA", WithImmediateTimer[int]())
+	suite.Require().NoError(err)
+	suite.Require().NotNil(runner)
+
+	_, err = runner.Run(testCtx, func(context.Context) (int, error) {
+		calls++
+		return -1, retryErr
+	})
+
+	suite.ErrorIs(err, retryErr)
+	suite.Equal(3, calls)
+}
+
+func TestRegistry(t *testing.T) {
+	suite.Run(t, new(RegistrySuite))
+}
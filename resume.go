@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"iter"
+)
+
+// OpenSeq opens a sequence, such as a paginated API or a stream, positioned just after
+// the given cursor.  Each element of the returned sequence is either an item or an error.
+// An error ends that sequence.
+type OpenSeq[V, C any] func(ctx context.Context, cursor C) iter.Seq2[V, error]
+
+// Resume returns a sequence that retries failures of the sequences created by open,
+// resuming each time from the position just after the last item yielded.  This allows
+// long-running consumers, such as bulk exports, to survive transient failures without
+// starting over:
+//
+//	items := retry.Resume(ctx, cfg, "", listPage, func(item Item) string {
+//		return item.ID
+//	}, retry.ClassifyForRetry)
+//
+//	for item, err := range items {
+//		if err != nil {
+//			return err // retries were exhausted, or the error wasn't retryable
+//		}
+//
+//		// process item ...
+//	}
+//
+// The first sequence is opened with the start cursor.  After each item is yielded, the
+// cursor function computes the position from which to resume, so no item is yielded twice.
+//
+// When a sequence yields an error, shouldRetry decides whether to retry it, or
+// DefaultTestErrorForRetry if shouldRetry is nil.  Pass a Classifier's ShouldRetry method,
+// or ClassifyForRetry, to stop on errors such as context cancelation.  If the error is
+// retryable, the policy created by pf is consulted, and the sequence is reopened once the
+// retry interval has passed.  Otherwise, the error is yielded and the returned sequence
+// ends.  If the context is canceled or the policy's time limit is reached while waiting,
+// that context error is yielded instead.
+//
+// A policy is only created once a sequence fails, and a new one is created whenever items
+// were yielded since the previous failure.  So, policy limits such as Config.MaxRetries and
+// Config.MaxElapsedTime apply to consecutive failures rather than to the entire sequence.
+//
+// If pf is nil, failures are never retried.  Waits use the Clock returned by ClockFromContext.
+func Resume[V, C any](ctx context.Context, pf PolicyFactory, start C, open OpenSeq[V, C], cursor func(V) C, shouldRetry func(error) bool) iter.Seq2[V, error] {
+	if pf == nil {
+		pf = Config{}
+	}
+
+	if shouldRetry == nil {
+		shouldRetry = DefaultTestErrorForRetry
+	}
+
+	return func(yield func(V, error) bool) {
+		r := resumer[V, C]{
+			ctx:         ctx,
+			pf:          pf,
+			shouldRetry: shouldRetry,
+			clock:       ClockFromContext(ctx),
+			open:        open,
+			cursor:      cursor,
+			yield:       yield,
+			cur:         start,
+		}
+
+		defer r.cancelPolicy()
+		r.run()
+	}
+}
+
+// resumer holds the state of a single iteration over a Resume sequence.
+type resumer[V, C any] struct {
+	ctx         context.Context
+	pf          PolicyFactory
+	shouldRetry func(error) bool
+	clock       Clock
+	open        OpenSeq[V, C]
+	cursor      func(V) C
+	yield       func(V, error) bool
+
+	// cur is the position after the last item yielded
+	cur C
+
+	// policy governs the current run of consecutive failures, and is nil if there is none
+	policy Policy
+}
+
+func (r *resumer[V, C]) cancelPolicy() {
+	if r.policy != nil {
+		r.policy.Cancel()
+		r.policy = nil
+	}
+}
+
+// run opens and consumes sequences until one ends without error, the consumer stops,
+// or a failure is not retried.
+func (r *resumer[V, C]) run() {
+	for {
+		progressed, stopped, err := r.stream()
+		switch {
+		case stopped || err == nil:
+			return
+
+		case progressed:
+			r.cancelPolicy()
+		}
+
+		if err = r.awaitRetry(err); err != nil {
+			var zero V
+			r.yield(zero, err)
+			return
+		}
+	}
+}
+
+// stream opens a sequence at the current cursor and yields its items.  The returned error
+// is the one that ended the sequence, or nil if the sequence completed or the consumer
+// stopped early.
+func (r *resumer[V, C]) stream() (progressed, stopped bool, err error) {
+	for v, err := range r.open(r.ctx, r.cur) {
+		if err != nil {
+			return progressed, false, err
+		}
+
+		r.cur = r.cursor(v)
+		progressed = true
+		if !r.yield(v, nil) {
+			return progressed, true, nil
+		}
+	}
+
+	return progressed, false, nil
+}
+
+// awaitRetry decides whether a failed sequence will be reopened and, if so, waits out the
+// retry interval.  A nil return indicates the sequence should be reopened.  Otherwise, the
+// returned error is the one to yield to the consumer.
+func (r *resumer[V, C]) awaitRetry(err error) error {
+	if !r.shouldRetry(err) {
+		return err
+	}
+
+	if r.policy == nil {
+		r.policy = r.pf.NewPolicy(r.ctx)
+	}
+
+	interval, ok := r.policy.Next()
+	if !ok {
+		return err
+	}
+
+	ch, stop := r.clock.NewTimer(interval)
+	select {
+	case <-r.policy.Context().Done():
+		stop()
+		return r.policy.Context().Err()
+
+	case <-ch:
+		return clockContextErr(r.policy.Context(), r.clock)
+	}
+}
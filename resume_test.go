@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResumeSuite struct {
+	CommonSuite
+
+	retryErr error
+
+	// opens records the cursor passed to each open
+	opens []int
+
+	// failures maps a position to the number of times the source fails there
+	failures map[int]int
+}
+
+func (suite *ResumeSuite) SetupTest() {
+	suite.retryErr = errors.New("should retry this")
+	suite.opens = nil
+	suite.failures = make(map[int]int)
+}
+
+// clockCtx returns a test context that carries a Clock whose timers fire immediately.
+func (suite *ResumeSuite) clockCtx() (context.Context, context.CancelFunc) {
+	testCtx, cancel := suite.testCtx()
+	return ContextWithClock(testCtx, &stoppedClock{now: time.Now()}), cancel
+}
+
+// open is an OpenSeq over the integers [cursor, 10) that fails as configured by suite.failures.
+func (suite *ResumeSuite) open(_ context.Context, cursor int) iter.Seq2[int, error] {
+	suite.opens = append(suite.opens, cursor)
+	return func(yield func(int, error) bool) {
+		for i := cursor; i < 10; i++ {
+			if suite.failures[i] > 0 {
+				suite.failures[i]--
+				yield(-1, suite.retryErr)
+				return
+			}
+
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+}
+
+func nextPosition(v int) int { return v + 1 }
+
+// cancelClock is a Clock that cancels a context instead of starting a timer, so that
+// the context is canceled while waiting.
+type cancelClock struct {
+	systemClock
+	cancel context.CancelFunc
+}
+
+func (cc cancelClock) NewTimer(time.Duration) (<-chan time.Time, func() bool) {
+	cc.cancel()
+	return nil, nopStop
+}
+
+// collect consumes a sequence, returning the items and the errors separately.
+func (suite *ResumeSuite) collect(seq iter.Seq2[int, error]) (items []int, errs []error) {
+	for v, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			items = append(items, v)
+		}
+	}
+
+	return
+}
+
+func (suite *ResumeSuite) TestNoFailures() {
+	ctx, _ := suite.clockCtx()
+	items, errs := suite.collect(Resume(ctx, Config{Interval: time.Second}, 0, suite.open, nextPosition, nil))
+	suite.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, items)
+	suite.Empty(errs)
+	suite.Equal([]int{0}, suite.opens)
+}
+
+func (suite *ResumeSuite) TestResume() {
+	ctx, _ := suite.clockCtx()
+	suite.failures[0] = 1
+	suite.failures[4] = 2
+	suite.failures[7] = 1
+
+	items, errs := suite.collect(Resume(ctx, Config{Interval: time.Second, MaxRetries: 2}, 0, suite.open, nextPosition, nil))
+	suite.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, items, "items must not be yielded twice")
+	suite.Empty(errs)
+	suite.Equal([]int{0, 0, 4, 4, 7}, suite.opens)
+}
+
+func (suite *ResumeSuite) TestStartCursor() {
+	ctx, _ := suite.clockCtx()
+	items, errs := suite.collect(Resume(ctx, nil, 7, suite.open, nextPosition, nil))
+	suite.Equal([]int{7, 8, 9}, items)
+	suite.Empty(errs)
+}
+
+func (suite *ResumeSuite) TestExhausted() {
+	ctx, _ := suite.clockCtx()
+	suite.failures[3] = 1
+	suite.failures[5] = 3
+
+	// the policy is renewed after progress, so MaxRetries applies to consecutive failures
+	items, errs := suite.collect(Resume(ctx, Config{Interval: time.Second, MaxRetries: 2}, 0, suite.open, nextPosition, nil))
+	suite.Equal([]int{0, 1, 2, 3, 4}, items)
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], suite.retryErr)
+	suite.Equal([]int{0, 3, 5, 5}, suite.opens)
+}
+
+func (suite *ResumeSuite) TestNotRetryable() {
+	ctx, _ := suite.clockCtx()
+	suite.retryErr = SetRetryable(suite.retryErr, false)
+	suite.failures[2] = 1
+
+	items, errs := suite.collect(Resume(ctx, Config{Interval: time.Second}, 0, suite.open, nextPosition, nil))
+	suite.Equal([]int{0, 1}, items)
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], suite.retryErr)
+	suite.Equal([]int{0}, suite.opens)
+}
+
+func (suite *ResumeSuite) TestShouldRetry() {
+	ctx, _ := suite.clockCtx()
+	suite.failures[2] = 1
+
+	var seen []error
+	items, errs := suite.collect(Resume(ctx, Config{Interval: time.Second}, 0, suite.open, nextPosition, func(err error) bool {
+		seen = append(seen, err)
+		return false
+	}))
+
+	suite.Equal([]int{0, 1}, items)
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], suite.retryErr, "a failure that shouldRetry rejects must be yielded")
+	suite.Equal([]error{suite.retryErr}, seen)
+	suite.Equal([]int{0}, suite.opens)
+}
+
+func (suite *ResumeSuite) TestBreak() {
+	ctx, _ := suite.clockCtx()
+	suite.failures[2] = 1
+
+	var items []int
+	for v, err := range Resume(ctx, Config{Interval: time.Second}, 0, suite.open, nextPosition, nil) {
+		suite.Require().NoError(err)
+		items = append(items, v)
+		if v == 4 {
+			break
+		}
+	}
+
+	suite.Equal([]int{0, 1, 2, 3, 4}, items)
+	suite.Equal([]int{0, 2}, suite.opens)
+}
+
+func (suite *ResumeSuite) TestCanceled() {
+	ctx, cancel := suite.clockCtx()
+	suite.failures[2] = 1
+
+	var errs []error
+	for v, err := range Resume(ctx, Config{Interval: time.Second}, 0, suite.open, nextPosition, nil) {
+		if err != nil {
+			errs = append(errs, err)
+		} else if v == 1 {
+			cancel()
+		}
+	}
+
+	// the policy refuses to retry, so the last error is yielded
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], suite.retryErr)
+	suite.Equal([]int{0}, suite.opens)
+}
+
+func (suite *ResumeSuite) TestCanceledWhileWaiting() {
+	testCtx, cancel := suite.testCtx()
+	ctx := ContextWithClock(testCtx, cancelClock{cancel: cancel})
+	suite.failures[2] = 1
+
+	items, errs := suite.collect(Resume(ctx, Config{Interval: time.Hour}, 0, suite.open, nextPosition, nil))
+	suite.Equal([]int{0, 1}, items)
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], context.Canceled)
+	suite.Equal([]int{0}, suite.opens)
+}
+
+func TestResume(t *testing.T) {
+	suite.Run(t, new(ResumeSuite))
+}
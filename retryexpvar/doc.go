@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package retryexpvar provides a retry.Metrics backend that publishes through the
+standard library's expvar package.
+
+Typical usage:
+
+	metrics := retryexpvar.Publish("retry")
+	runner, _ := retry.NewRunner(
+		retry.WithMetrics[int](metrics, "inventory"),
+		retry.WithPolicyFactory[int](config),
+	)
+
+Each metric appears in the published map under a key that includes its labels, e.g.
+retry_retries_total{runner="inventory"}.
+*/
+package retryexpvar
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryexpvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xmidt-org/retry"
+)
+
+// Key produces the expvar key for a metric, e.g. retry_runs_total{runner="inventory"}.
+// Labels appear in the order given.
+func Key(name string, labels ...retry.Label) string {
+	if len(labels) == 0 {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(l.Name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(l.Value))
+	}
+
+	b.WriteByte('}')
+	return b.String()
+}
+
+// histogram is an expvar.Var that summarizes observations.  The expvar package has
+// no histogram type, so the count, sum, min, and max are published.
+type histogram struct {
+	lock  sync.Mutex
+	count uint64
+	sum   float64
+	min   float64
+	max   float64
+}
+
+func (h *histogram) Observe(value float64) {
+	defer h.lock.Unlock()
+	h.lock.Lock()
+	if h.count == 0 {
+		h.min, h.max = value, value
+	} else {
+		h.min, h.max = math.Min(h.min, value), math.Max(h.max, value)
+	}
+
+	h.count++
+	h.sum += value
+}
+
+// String returns the JSON representation of this histogram, as required by expvar.Var.
+func (h *histogram) String() string {
+	defer h.lock.Unlock()
+	h.lock.Lock()
+	data, _ := json.Marshal(struct {
+		Count uint64  `json:"count"`
+		Sum   float64 `json:"sum"`
+		Min   float64 `json:"min"`
+		Max   float64 `json:"max"`
+	}{
+		Count: h.count,
+		Sum:   h.sum,
+		Min:   h.min,
+		Max:   h.max,
+	})
+
+	return string(data)
+}
+
+// Metrics is a retry.Metrics backend that stores each metric in an expvar.Map.  Counters
+// are expvar.Float values.  Histograms are published as JSON objects with the count, sum,
+// min, and max of their observations.
+type Metrics struct {
+	lock sync.Mutex
+	m    *expvar.Map
+}
+
+var _ retry.Metrics = (*Metrics)(nil)
+
+// New creates a Metrics backend that stores metrics in the given map.  The map does
+// not have to be published, which is useful for tests.
+func New(m *expvar.Map) *Metrics {
+	return &Metrics{
+		m: m,
+	}
+}
+
+// Publish creates a Metrics backend that stores metrics in a new expvar.Map published
+// under the given name.  Like expvar.Publish, this function panics if the name is already
+// in use.
+func Publish(name string) *Metrics {
+	return New(expvar.NewMap(name))
+}
+
+// Map returns the expvar.Map that holds the metrics.
+func (m *Metrics) Map() *expvar.Map {
+	return m.m
+}
+
+// getOrSet returns the existing variable for a key, or stores and returns the one
+// created by newVar.
+func (m *Metrics) getOrSet(key string, newVar func() expvar.Var) expvar.Var {
+	defer m.lock.Unlock()
+	m.lock.Lock()
+	v := m.m.Get(key)
+	if v == nil {
+		v = newVar()
+		m.m.Set(key, v)
+	}
+
+	return v
+}
+
+// Counter returns the expvar.Float counter with the given name and labels, creating it
+// if necessary.  If a non-counter variable already has the same key, this method panics.
+func (m *Metrics) Counter(name string, labels ...retry.Label) retry.Counter {
+	return m.getOrSet(Key(name, labels...), func() expvar.Var {
+		return new(expvar.Float)
+	}).(*expvar.Float)
+}
+
+// Histogram returns the histogram with the given name and labels, creating it if necessary.
+// If a non-histogram variable already has the same key, this method panics.
+func (m *Metrics) Histogram(name string, labels ...retry.Label) retry.Histogram {
+	return m.getOrSet(Key(name, labels...), func() expvar.Var {
+		return new(histogram)
+	}).(*histogram)
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryexpvar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+
+	metrics *Metrics
+}
+
+func (suite *MetricsSuite) SetupTest() {
+	suite.metrics = New(new(expvar.Map).Init())
+}
+
+func (suite *MetricsSuite) value(key string) expvar.Var {
+	v := suite.metrics.Map().Get(key)
+	suite.Require().NotNil(v, "no variable for %s", key)
+	return v
+}
+
+func (suite *MetricsSuite) histogramValue(key string) (h struct {
+	Count uint64  `json:"count"`
+	Sum   float64 `json:"sum"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+},
+) {
+	suite.Require().NoError(json.Unmarshal([]byte(suite.value(key).String()), &h))
+	return
+}
+
+func (suite *MetricsSuite) TestKey() {
+	suite.Equal("name", Key("name"))
+	suite.Equal(
+		`name{runner="test",reason="say \"what\""}`,
+		Key("name", retry.Label{Name: "runner", Value: "test"}, retry.Label{Name: "reason", Value: `say "what"`}),
+	)
+}
+
+func (suite *MetricsSuite) TestCounter() {
+	label := retry.Label{Name: "runner", Value: "test"}
+	c := suite.metrics.Counter("counter", label)
+	suite.Same(c, suite.metrics.Counter("counter", label))
+
+	c.Add(1.0)
+	c.Add(2.5)
+	suite.Equal("3.5", suite.value(`counter{runner="test"}`).String())
+}
+
+func (suite *MetricsSuite) TestHistogram() {
+	h := suite.metrics.Histogram("histogram")
+	suite.Same(h, suite.metrics.Histogram("histogram"))
+
+	actual := suite.histogramValue("histogram")
+	suite.Zero(actual.Count)
+
+	h.Observe(2.0)
+	h.Observe(1.0)
+	h.Observe(6.0)
+
+	actual = suite.histogramValue("histogram")
+	suite.Equal(uint64(3), actual.Count)
+	suite.Equal(9.0, actual.Sum)
+	suite.Equal(1.0, actual.Min)
+	suite.Equal(6.0, actual.Max)
+}
+
+// publishCount ensures unique published names when tests are run repeatedly.
+var publishCount atomic.Int32
+
+func (suite *MetricsSuite) TestPublish() {
+	name := fmt.Sprintf("retryexpvar_test_%d", publishCount.Add(1))
+	m := Publish(name)
+	suite.Same(expvar.Get(name), m.Map())
+	suite.Panics(func() { Publish(name) })
+}
+
+func (suite *MetricsSuite) TestRunner() {
+	var (
+		retryErr    = errors.New("should retry this")
+		calls       int
+		runner, err = retry.NewRunner(
+			retry.WithImmediateTimer[int](),
+			retry.WithMetrics[int](suite.metrics, "test"),
+			retry.WithPolicyFactory[int](retry.Config{
+				Interval:   time.Second,
+				MaxRetries: 2,
+			}),
+		)
+	)
+
+	suite.Require().NoError(err)
+	_, err = runner.Run(context.Background(), func(context.Context) (int, error) {
+		calls++
+		return -1, retryErr
+	})
+
+	suite.ErrorIs(err, retryErr)
+	suite.Equal(3, calls)
+
+	suite.Equal("1", suite.value(`retry_runs_total{runner="test"}`).String())
+	suite.Equal("3", suite.value(`retry_attempts_total{runner="test"}`).String())
+	suite.Equal("2", suite.value(`retry_retries_total{runner="test"}`).String())
+	suite.Equal("0", suite.value(`retry_successes_total{runner="test"}`).String())
+	suite.Equal("1", suite.value(`retry_give_ups_total{runner="test",reason="exhausted"}`).String())
+	suite.Equal("0", suite.value(`retry_give_ups_total{runner="test",reason="permanent"}`).String())
+	suite.Equal(3.0, suite.histogramValue(`retry_attempts_per_run{runner="test"}`).Sum)
+	suite.Equal(2.0, suite.histogramValue(`retry_sleep_seconds{runner="test"}`).Sum)
+	suite.Equal(uint64(1), suite.histogramValue(`retry_run_duration_seconds{runner="test"}`).Count)
+}
+
+func TestMetrics(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
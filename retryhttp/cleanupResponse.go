@@ -21,3 +21,14 @@ func CleanupResponse(a retry.Attempt[*http.Response]) {
 		a.Result.Body = nil
 	}
 }
+
+// CleanupHedgedResponse is a retry.HedgeCleanup that drains and closes the response
+// body of a losing hedged attempt.  Use this with retry.WithHedgeCleanup when a Client's
+// runner is configured with retry.WithHedging.
+func CleanupHedgedResponse(response *http.Response, _ error) {
+	if response != nil && response.Body != nil {
+		io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+		response.Body = nil
+	}
+}
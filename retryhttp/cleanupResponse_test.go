@@ -69,3 +69,34 @@ func (suite *CleanupResponseSuite) TestNotDone() {
 func TestCleanupResponse(t *testing.T) {
 	suite.Run(t, new(CleanupResponseSuite))
 }
+
+type CleanupHedgedResponseSuite struct {
+	suite.Suite
+}
+
+func (suite *CleanupHedgedResponseSuite) TestNilResponse() {
+	suite.NotPanics(func() {
+		CleanupHedgedResponse(nil, context.Canceled)
+	})
+}
+
+func (suite *CleanupHedgedResponseSuite) TestWithBody() {
+	var (
+		body = &body{
+			Buffer: bytes.NewBufferString("test"),
+		}
+
+		response = &http.Response{
+			Body: body,
+		}
+	)
+
+	CleanupHedgedResponse(response, nil)
+	suite.Empty(body.String())
+	suite.True(body.closed)
+	suite.Nil(response.Body)
+}
+
+func TestCleanupHedgedResponse(t *testing.T) {
+	suite.Run(t, new(CleanupHedgedResponseSuite))
+}
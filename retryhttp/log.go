@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryhttp
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// LogKeyStatusCode is the log key that ResponseAttrs uses for the HTTP status code.
+const LogKeyStatusCode = "statusCode"
+
+// ResponseAttrs is a hook for retry.WithResultAttrs that logs the status code of
+// a response.  A nil response, as with a failed attempt, produces no attributes.
+func ResponseAttrs(response *http.Response) []slog.Attr {
+	if response == nil {
+		return nil
+	}
+
+	return []slog.Attr{
+		slog.Int(LogKeyStatusCode, response.StatusCode),
+	}
+}
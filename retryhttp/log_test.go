@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryhttp
+
+import (
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResponseAttrsSuite struct {
+	suite.Suite
+}
+
+func (suite *ResponseAttrsSuite) TestNilResponse() {
+	suite.Empty(ResponseAttrs(nil))
+}
+
+func (suite *ResponseAttrsSuite) TestResponse() {
+	suite.Equal(
+		[]slog.Attr{slog.Int(LogKeyStatusCode, http.StatusTooManyRequests)},
+		ResponseAttrs(&http.Response{StatusCode: http.StatusTooManyRequests}),
+	)
+}
+
+func TestResponseAttrs(t *testing.T) {
+	suite.Run(t, new(ResponseAttrsSuite))
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build go1.25
+
+package retryhttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+// statusClient is an in-memory HTTPClient that responds with a scripted sequence of
+// status codes.  The last status code is repeated once the sequence is exhausted.
+type statusClient struct {
+	statusCodes []int
+	times       []time.Time
+}
+
+func (sc *statusClient) Do(request *http.Request) (*http.Response, error) {
+	i := min(len(sc.times), len(sc.statusCodes)-1)
+	sc.times = append(sc.times, time.Now())
+	return &http.Response{
+		StatusCode: sc.statusCodes[i],
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    request,
+	}, nil
+}
+
+// SynctestSuite verifies that a Client honors real Config values with the fake time
+// inside a testing/synctest bubble.
+type SynctestSuite struct {
+	suite.Suite
+}
+
+// bubble runs f inside a synctest bubble.  While f runs, suite.T() refers to the bubble's test.
+func (suite *SynctestSuite) bubble(f func()) {
+	outer := suite.T()
+	synctest.Test(outer, func(t *testing.T) {
+		suite.SetT(t)
+		defer suite.SetT(outer)
+		f()
+	})
+}
+
+func (suite *SynctestSuite) newClient(hc HTTPClient, cfg retry.Config) *Client {
+	runner, err := retry.NewRunner(
+		retry.WithPolicyFactory[*http.Response](cfg),
+		WithShouldRetry(http.StatusServiceUnavailable),
+		retry.WithOnAttempt(CleanupResponse),
+	)
+
+	suite.Require().NoError(err)
+	client, err := NewClient(
+		WithHTTPClient(hc),
+		WithRunner(runner),
+	)
+
+	suite.Require().NoError(err)
+	return client
+}
+
+func (suite *SynctestSuite) TestRetriesThenSucceeds() {
+	suite.bubble(func() {
+		var (
+			start = time.Now()
+			hc    = &statusClient{
+				statusCodes: []int{
+					http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK,
+				},
+			}
+
+			client = suite.newClient(hc, retry.Config{
+				Interval:   30 * time.Second,
+				Multiplier: 2.0,
+				MaxRetries: 5,
+			})
+		)
+
+		request, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+		suite.Require().NoError(err)
+
+		response, err := client.Do(request)
+		suite.Require().NoError(err)
+		suite.Equal(http.StatusOK, response.StatusCode)
+		response.Body.Close()
+
+		suite.Equal(
+			[]time.Time{start, start.Add(30 * time.Second), start.Add(90 * time.Second)},
+			hc.times,
+		)
+	})
+}
+
+func (suite *SynctestSuite) TestRequestDeadline() {
+	suite.bubble(func() {
+		var (
+			start = time.Now()
+			hc    = &statusClient{
+				statusCodes: []int{http.StatusServiceUnavailable},
+			}
+
+			client = suite.newClient(hc, retry.Config{
+				Interval: 30 * time.Second,
+			})
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+		defer cancel()
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/", nil)
+		suite.Require().NoError(err)
+
+		response, err := client.Do(request)
+		suite.Nil(response)
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Equal(45*time.Second, time.Since(start))
+		suite.Len(hc.times, 2)
+	})
+}
+
+func TestSynctest(t *testing.T) {
+	suite.Run(t, new(SynctestSuite))
+}
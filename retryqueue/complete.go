@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"context"
+
+	"github.com/xmidt-org/retry"
+)
+
+// complete records the outcome of an attempt, returning any error from writing the journal.
+func (q *Queue) complete(ctx context.Context, t Task, err error) error {
+	switch {
+	case err == nil:
+		return q.remove(t.ID)
+
+	case ctx.Err() != nil:
+		// the queue is stopping, so this attempt doesn't count
+		q.lock.Lock()
+		q.schedule(q.pending[t.ID])
+		q.lock.Unlock()
+		return nil
+	}
+
+	t.Attempts++
+	t.LastError = err.Error()
+	if retry.DefaultTestErrorForRetry(err) {
+		if next, ok := nextAttempt(q.policy, t, q.clock.Now()); ok {
+			t.NextAttempt = next
+			return q.retry(t)
+		}
+	}
+
+	// the dead letter is delivered before the task is removed, so that a task
+	// is never lost if the process stops in between
+	if q.deadLetter != nil {
+		q.deadLetter(ctx, t, err)
+	}
+
+	return q.remove(t.ID)
+}
+
+// retry durably records a failed attempt and reschedules the task.
+func (q *Queue) retry(t Task) error {
+	defer q.lock.Unlock()
+	q.lock.Lock()
+	if q.journal == nil {
+		return ErrClosed
+	}
+
+	if err := q.journal.put(t); err != nil {
+		return err
+	}
+
+	*q.pending[t.ID] = t
+	q.schedule(q.pending[t.ID])
+	return q.compactIfNeeded()
+}
+
+// remove durably removes a task from the queue.
+func (q *Queue) remove(id string) error {
+	defer q.lock.Unlock()
+	q.lock.Lock()
+	if q.journal == nil {
+		return ErrClosed
+	}
+
+	if err := q.journal.del(id); err != nil {
+		return err
+	}
+
+	delete(q.pending, id)
+	return q.compactIfNeeded()
+}
+
+// compactIfNeeded compacts the journal once most of its records are obsolete.  The lock
+// must be held.
+func (q *Queue) compactIfNeeded() error {
+	if q.journal.records < minCompactRecords || q.journal.records <= 2*len(q.pending) {
+		return nil
+	}
+
+	pending := make(map[string]Task, len(q.pending))
+	for id, t := range q.pending {
+		pending[id] = *t
+	}
+
+	return q.journal.compact(pending)
+}
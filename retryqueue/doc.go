@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package retryqueue provides a durable retry queue whose pending tasks survive process
+restarts.  Each task is an opaque payload, e.g. a serialized webhook, that is stored in a
+local append-only journal along with its attempt count and the time of its next attempt.
+
+Typical usage:
+
+	q, err := retryqueue.Open(
+		"/var/lib/myservice/webhooks.journal",
+		func(ctx context.Context, t retryqueue.Task) error {
+			return deliver(ctx, t.Payload)
+		},
+		retryqueue.WithPolicy(retry.Config{
+			Interval:       time.Second,
+			Multiplier:     2.0,
+			MaxInterval:    5 * time.Minute,
+			MaxElapsedTime: 24 * time.Hour,
+		}),
+		retryqueue.WithWorkers(4),
+		retryqueue.WithDeadLetter(func(ctx context.Context, t retryqueue.Task, err error) {
+			// record or alert on the task that could not be delivered ...
+		}),
+	)
+
+	if err != nil {
+		// handle the error ...
+	}
+
+	defer q.Close()
+	go q.Run(ctx) // returns once ctx is canceled
+
+	q.Enqueue(payload)
+
+Tasks are delivered at least once.  If the process stops while a task is being attempted,
+that task is attempted again after the queue is reopened.
+*/
+package retryqueue
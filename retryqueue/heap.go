@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+// taskHeap orders the tasks that are waiting for an attempt by NextAttempt.  It implements
+// heap.Interface.
+type taskHeap []*Task
+
+func (th taskHeap) Len() int { return len(th) }
+
+func (th taskHeap) Less(i, j int) bool {
+	return th[i].NextAttempt.Before(th[j].NextAttempt)
+}
+
+func (th taskHeap) Swap(i, j int) { th[i], th[j] = th[j], th[i] }
+
+func (th *taskHeap) Push(x any) { *th = append(*th, x.(*Task)) }
+
+func (th *taskHeap) Pop() any {
+	old := *th
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*th = old[:n-1]
+	return t
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	opPut = "put"
+	opDel = "del"
+
+	// journalFileMode is the permissions for journal files, which may hold sensitive payloads.
+	journalFileMode = 0o600
+)
+
+// ErrCorruptJournal indicates that a journal could not be read.  Only the last record of
+// a journal may be incomplete, since that happens when a process stops while writing it.
+var ErrCorruptJournal = errors.New("corrupt retry queue journal")
+
+// record is a single line in a journal.  A put record stores the current state of a task,
+// replacing any earlier put record for the same task.  A del record removes a task.
+type record struct {
+	Op   string `json:"op"`
+	Task *Task  `json:"task,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+// journal is an append-only file of JSON records, one per line.  A journal is not safe
+// for concurrent use.
+type journal struct {
+	path string
+	f    *os.File
+
+	// records is the number of records in the file
+	records int
+}
+
+// readJournal replays the journal file at path, returning the tasks that are still pending.
+// A missing file is treated as an empty journal.
+func readJournal(path string) (map[string]Task, error) {
+	pending := make(map[string]Task)
+	data, err := os.ReadFile(path) //nolint:gosec // the path is supplied by the application
+	if errors.Is(err, os.ErrNotExist) {
+		return pending, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(data, []byte{'\n'})
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		var r record
+		if err := json.Unmarshal(line, &r); err != nil {
+			if i == len(lines)-1 {
+				break // an incomplete, final record
+			}
+
+			return nil, fmt.Errorf("%w: %s line %d: %w", ErrCorruptJournal, path, i+1, err)
+		}
+
+		switch {
+		case r.Op == opPut && r.Task != nil:
+			pending[r.Task.ID] = *r.Task
+
+		case r.Op == opDel:
+			delete(pending, r.ID)
+
+		default:
+			return nil, fmt.Errorf("%w: %s line %d: invalid record", ErrCorruptJournal, path, i+1)
+		}
+	}
+
+	return pending, nil
+}
+
+// openJournal replays the journal at path, then compacts it so that it contains only
+// the pending tasks.
+func openJournal(path string) (*journal, map[string]Task, error) {
+	pending, err := readJournal(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	j := &journal{path: path}
+	if err := j.compact(pending); err != nil {
+		return nil, nil, err
+	}
+
+	return j, pending, nil
+}
+
+// writeRecords writes records to w, one per line.
+func writeRecords(w *bufio.Writer, records ...record) error {
+	for _, r := range records {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+
+		data = append(data, '\n')
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// append durably writes records to the end of the journal.
+func (j *journal) append(records ...record) error {
+	if err := writeRecords(bufio.NewWriter(j.f), records...); err != nil {
+		return err
+	}
+
+	j.records += len(records)
+	return j.f.Sync()
+}
+
+func (j *journal) put(t Task) error {
+	return j.append(record{Op: opPut, Task: &t})
+}
+
+func (j *journal) del(id string) error {
+	return j.append(record{Op: opDel, ID: id})
+}
+
+// compact atomically replaces the journal file with one that holds a put record
+// for each pending task.  The journal is left open for appending.
+func (j *journal) compact(pending map[string]Task) error {
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, journalFileMode) //nolint:gosec // the path is supplied by the application
+	if err != nil {
+		return err
+	}
+
+	records := make([]record, 0, len(pending))
+	for _, t := range pending {
+		records = append(records, record{Op: opPut, Task: &t})
+	}
+
+	err = writeRecords(bufio.NewWriter(f), records...)
+	if err == nil {
+		err = f.Sync()
+	}
+
+	if err == nil {
+		err = os.Rename(tmp, j.path)
+	}
+
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	syncDir(filepath.Dir(j.path))
+	if j.f != nil {
+		_ = j.f.Close()
+	}
+
+	j.f, j.records = f, len(records)
+	return nil
+}
+
+// syncDir makes a rename within a directory durable.  Not all platforms support this,
+// so errors are ignored.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil { //nolint:gosec // the path is supplied by the application
+		_ = d.Sync()
+		_ = d.Close()
+	}
+}
+
+func (j *journal) close() error {
+	return j.f.Close()
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JournalSuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (suite *JournalSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "test.journal")
+}
+
+func (suite *JournalSuite) newTask(id string) Task {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	return Task{
+		ID:          id,
+		Payload:     []byte("payload " + id),
+		Created:     now,
+		NextAttempt: now.Add(time.Minute),
+	}
+}
+
+func (suite *JournalSuite) openJournal() (*journal, map[string]Task) {
+	j, pending, err := openJournal(suite.path)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(j)
+	return j, pending
+}
+
+func (suite *JournalSuite) TestMissingFile() {
+	j, pending := suite.openJournal()
+	defer j.close()
+
+	suite.Empty(pending)
+	suite.FileExists(suite.path)
+}
+
+func (suite *JournalSuite) TestReplay() {
+	j, _ := suite.openJournal()
+	a, b, c := suite.newTask("a"), suite.newTask("b"), suite.newTask("c")
+	suite.Require().NoError(j.put(a))
+	suite.Require().NoError(j.put(b))
+	suite.Require().NoError(j.put(c))
+
+	b.Attempts, b.LastError = 1, "expected"
+	suite.Require().NoError(j.put(b))
+	suite.Require().NoError(j.del(a.ID))
+	suite.Equal(5, j.records)
+	suite.Require().NoError(j.close())
+
+	j, pending := suite.openJournal()
+	defer j.close()
+
+	suite.Equal(map[string]Task{"b": b, "c": c}, pending)
+	suite.Equal(2, j.records, "the journal must be compacted when opened")
+}
+
+func (suite *JournalSuite) TestIncompleteRecord() {
+	j, _ := suite.openJournal()
+	suite.Require().NoError(j.put(suite.newTask("a")))
+	suite.Require().NoError(j.close())
+
+	f, err := os.OpenFile(suite.path, os.O_APPEND|os.O_WRONLY, 0)
+	suite.Require().NoError(err)
+	_, err = f.WriteString(`{"op":"put","task":{"id":"b"`)
+	suite.Require().NoError(err)
+	suite.Require().NoError(f.Close())
+
+	j, pending := suite.openJournal()
+	defer j.close()
+
+	suite.Len(pending, 1)
+	suite.Contains(pending, "a")
+}
+
+func (suite *JournalSuite) TestCorrupt() {
+	suite.Require().NoError(os.WriteFile(
+		suite.path,
+		[]byte("this is not json\n{\"op\":\"del\",\"id\":\"a\"}\n"),
+		journalFileMode,
+	))
+
+	j, pending, err := openJournal(suite.path)
+	suite.ErrorIs(err, ErrCorruptJournal)
+	suite.Nil(j)
+	suite.Nil(pending)
+}
+
+func (suite *JournalSuite) TestInvalidRecord() {
+	suite.Require().NoError(os.WriteFile(
+		suite.path,
+		[]byte("{\"op\":\"put\"}\n{\"op\":\"del\",\"id\":\"a\"}\n"),
+		journalFileMode,
+	))
+
+	_, _, err := openJournal(suite.path)
+	suite.ErrorIs(err, ErrCorruptJournal)
+}
+
+func TestJournal(t *testing.T) {
+	suite.Run(t, new(JournalSuite))
+}
+
+func writeFile(path, contents string) error {
+	return os.WriteFile(path, []byte(contents), journalFileMode)
+}
@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"container/heap"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/retry"
+)
+
+// DefaultWorkers is the number of tasks a Queue attempts concurrently if WithWorkers is not used.
+const DefaultWorkers = 1
+
+// minCompactRecords is the smallest journal that is compacted while a Queue is open.  Larger
+// journals are compacted once fewer than half of their records describe pending tasks.
+const minCompactRecords = 1000
+
+// ErrClosed is returned by Queue methods after the Queue has been closed.
+var ErrClosed = errors.New("retry queue is closed")
+
+// Option is a configurable option for a Queue.
+type Option interface {
+	apply(*Queue) error
+}
+
+type optionFunc func(*Queue) error
+
+func (of optionFunc) apply(q *Queue) error { return of(q) }
+
+// WithPolicy sets the policy that determines when failed tasks are retried.  Every field of
+// the Config is honored, with MaxElapsedTime measured from the time each task was enqueued.
+//
+// As with a retry.Runner, a Queue without a policy never retries tasks.
+func WithPolicy(cfg retry.Config) Option {
+	return optionFunc(func(q *Queue) error {
+		q.policy = cfg
+		return nil
+	})
+}
+
+// WithWorkers sets the number of tasks that are attempted concurrently.  If n is nonpositive,
+// DefaultWorkers is used.
+func WithWorkers(n int) Option {
+	return optionFunc(func(q *Queue) error {
+		q.workers = n
+		if q.workers < 1 {
+			q.workers = DefaultWorkers
+		}
+
+		return nil
+	})
+}
+
+// WithDeadLetter sets the DeadLetter that receives tasks that did not succeed, either because
+// the policy was exhausted or because an error was not retryable.  By default, such tasks are
+// discarded.
+func WithDeadLetter(dl DeadLetter) Option {
+	return optionFunc(func(q *Queue) error {
+		q.deadLetter = dl
+		return nil
+	})
+}
+
+// WithClock sets the Clock used to schedule attempts.  This option is primarily useful for
+// testing.  If c is nil, retry.SystemClock is used.
+func WithClock(c retry.Clock) Option {
+	return optionFunc(func(q *Queue) error {
+		q.clock = c
+		if q.clock == nil {
+			q.clock = retry.SystemClock
+		}
+
+		return nil
+	})
+}
+
+// Queue is a durable retry queue backed by a journal file.  A Queue is safe for concurrent use.
+type Queue struct {
+	handler    Handler
+	deadLetter DeadLetter
+	policy     retry.Config
+	workers    int
+	clock      retry.Clock
+
+	lock    sync.Mutex
+	journal *journal
+
+	// pending holds every task in the queue, including those being attempted
+	pending map[string]*Task
+
+	// waiting holds the tasks that are not being attempted
+	waiting taskHeap
+
+	// changed is closed and replaced each time a task becomes available for an attempt
+	changed chan struct{}
+}
+
+// Open opens the journal file at path, creating it if necessary, and returns a Queue that
+// holds any tasks still pending in it.  The given Handler performs each task.  Tasks are
+// not attempted until Run is called.
+//
+// Only one Queue may use a given journal file at a time.
+func Open(path string, h Handler, opts ...Option) (*Queue, error) {
+	q := &Queue{
+		handler: h,
+		workers: DefaultWorkers,
+		clock:   retry.SystemClock,
+		changed: make(chan struct{}),
+	}
+
+	for _, o := range opts {
+		if err := o.apply(q); err != nil {
+			return nil, err
+		}
+	}
+
+	j, pending, err := openJournal(path)
+	if err != nil {
+		return nil, err
+	}
+
+	q.journal = j
+	q.pending = make(map[string]*Task, len(pending))
+	for id, t := range pending {
+		q.pending[id] = &t
+		q.waiting = append(q.waiting, &t)
+	}
+
+	heap.Init(&q.waiting)
+	return q, nil
+}
+
+// newID generates a random task identifier.
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b[:]), nil
+}
+
+// Enqueue durably adds a task with the given payload, returning the task's ID.  The task
+// is due for its first attempt immediately.  Once this method returns without error, the
+// task survives a restart of the process.
+func (q *Queue) Enqueue(payload []byte) (string, error) {
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	now := q.clock.Now()
+	t := &Task{
+		ID:          id,
+		Payload:     append([]byte(nil), payload...),
+		Created:     now,
+		NextAttempt: now,
+	}
+
+	defer q.lock.Unlock()
+	q.lock.Lock()
+	if q.journal == nil {
+		return "", ErrClosed
+	}
+
+	if err := q.journal.put(*t); err != nil {
+		return "", err
+	}
+
+	q.pending[id] = t
+	q.schedule(t)
+	return id, nil
+}
+
+// Len returns the number of tasks in this queue, including any being attempted.
+func (q *Queue) Len() int {
+	defer q.lock.Unlock()
+	q.lock.Lock()
+	return len(q.pending)
+}
+
+// Close closes the journal.  Close must not be called until Run has returned.
+func (q *Queue) Close() error {
+	defer q.lock.Unlock()
+	q.lock.Lock()
+	if q.journal == nil {
+		return ErrClosed
+	}
+
+	err := q.journal.close()
+	q.journal = nil
+	return err
+}
+
+// schedule makes a task available for an attempt.  The lock must be held.
+func (q *Queue) schedule(t *Task) {
+	heap.Push(&q.waiting, t)
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// Run attempts tasks as they become due until the context is canceled, using the configured
+// number of workers.  The Handler's context is canceled at the same time.  Tasks whose attempts
+// fail because of that cancelation are not charged an attempt.
+//
+// Run returns the context's error once all workers have stopped.  If the journal cannot be
+// written, Run stops and returns that error instead.  Run must not be called concurrently.
+func (q *Queue) Run(ctx context.Context) error {
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	for range q.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := q.work(runCtx); err != nil {
+				cancel(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return context.Cause(runCtx)
+}
+
+// work is a worker's loop, which returns any error from writing the journal.
+func (q *Queue) work(ctx context.Context) error {
+	for {
+		t, ok := q.claim(ctx)
+		if !ok {
+			return nil
+		}
+
+		if err := q.complete(ctx, t, q.handler(ctx, t)); err != nil {
+			return err
+		}
+	}
+}
+
+// claim waits for a task to become due, removing it from the waiting tasks.  This method
+// returns false if the context is canceled first.
+func (q *Queue) claim(ctx context.Context) (Task, bool) {
+	for ctx.Err() == nil {
+		q.lock.Lock()
+		t, wait := q.nextDue()
+		changed := q.changed
+		q.lock.Unlock()
+
+		if t != nil {
+			return *t, true
+		}
+
+		var (
+			timer <-chan time.Time
+			stop  = func() bool { return true }
+		)
+
+		if wait > 0 {
+			timer, stop = q.clock.NewTimer(wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			stop()
+
+		case <-changed:
+			stop()
+
+		case <-timer:
+		}
+	}
+
+	return Task{}, false
+}
+
+// nextDue removes and returns the earliest waiting task if it is due.  Otherwise, this
+// method returns the time until the earliest task is due, or zero if no tasks are waiting.
+// The lock must be held.
+func (q *Queue) nextDue() (*Task, time.Duration) {
+	if len(q.waiting) == 0 {
+		return nil, 0
+	}
+
+	wait := q.waiting[0].NextAttempt.Sub(q.clock.Now())
+	if wait > 0 {
+		return nil, wait
+	}
+
+	return heap.Pop(&q.waiting).(*Task), 0
+}
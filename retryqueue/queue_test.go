@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+	"github.com/xmidt-org/retry/retrytest"
+)
+
+// attemptResult is what a test Handler returns for an attempt.
+type attemptResult struct {
+	task Task
+	err  error
+}
+
+type QueueSuite struct {
+	suite.Suite
+
+	path     string
+	clock    *retrytest.FakeClock
+	retryErr error
+
+	// attempts receives each Task passed to the Handler
+	attempts chan Task
+
+	// results supplies the error the Handler returns for each attempt
+	results chan error
+
+	deadLock    sync.Mutex
+	deadLetters []attemptResult
+}
+
+func (suite *QueueSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "test.journal")
+	suite.clock = retrytest.NewFakeClock(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+	suite.retryErr = errors.New("should retry this")
+	suite.attempts = make(chan Task)
+	suite.results = make(chan error)
+	suite.deadLetters = nil
+}
+
+func (suite *QueueSuite) handler(ctx context.Context, t Task) error {
+	suite.attempts <- t
+	select {
+	case err := <-suite.results:
+		return err
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (suite *QueueSuite) deadLetter(_ context.Context, t Task, err error) {
+	suite.deadLock.Lock()
+	suite.deadLetters = append(suite.deadLetters, attemptResult{task: t, err: err})
+	suite.deadLock.Unlock()
+}
+
+func (suite *QueueSuite) open(cfg retry.Config) *Queue {
+	q, err := Open(
+		suite.path,
+		suite.handler,
+		WithPolicy(cfg),
+		WithClock(suite.clock),
+		WithDeadLetter(suite.deadLetter),
+		WithWorkers(1),
+	)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(q)
+	return q
+}
+
+// run starts the queue, returning a function that stops it and returns the result of Run.
+func (suite *QueueSuite) run(q *Queue) func() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Run(ctx)
+	}()
+
+	return func() error {
+		cancel()
+		return <-done
+	}
+}
+
+// attempt waits for the Handler to be invoked, then has it return err.
+func (suite *QueueSuite) attempt(err error) Task {
+	t := <-suite.attempts
+	suite.results <- err
+	return t
+}
+
+// waitForLen waits until the queue holds n tasks.
+func (suite *QueueSuite) waitForLen(q *Queue, n int) {
+	suite.Eventually(
+		func() bool { return q.Len() == n },
+		time.Second,
+		time.Millisecond,
+	)
+}
+
+func (suite *QueueSuite) TestSuccess() {
+	q := suite.open(retry.Config{Interval: time.Minute})
+	stop := suite.run(q)
+
+	id, err := q.Enqueue([]byte("hello"))
+	suite.Require().NoError(err)
+	suite.NotEmpty(id)
+
+	t := suite.attempt(nil)
+	suite.Equal(id, t.ID)
+	suite.Equal([]byte("hello"), t.Payload)
+	suite.Zero(t.Attempts)
+	suite.Equal(suite.clock.Now(), t.Created)
+
+	suite.waitForLen(q, 0)
+	suite.ErrorIs(stop(), context.Canceled)
+	suite.Require().NoError(q.Close())
+
+	q = suite.open(retry.Config{})
+	defer q.Close()
+	suite.Zero(q.Len(), "a successful task must not be replayed")
+	suite.Empty(suite.deadLetters)
+}
+
+func (suite *QueueSuite) TestRetry() {
+	q := suite.open(retry.Config{Interval: time.Minute, MaxRetries: 2})
+	defer q.Close()
+	stop := suite.run(q)
+
+	id, err := q.Enqueue([]byte("hello"))
+	suite.Require().NoError(err)
+	suite.attempt(suite.retryErr)
+
+	// the worker must wait for the retry interval
+	suite.clock.BlockUntil(1)
+	suite.clock.Advance(time.Minute)
+
+	t := suite.attempt(nil)
+	suite.Equal(id, t.ID)
+	suite.Equal(1, t.Attempts)
+	suite.Equal(suite.retryErr.Error(), t.LastError)
+	suite.Equal(suite.clock.Now(), t.NextAttempt)
+
+	suite.waitForLen(q, 0)
+	suite.ErrorIs(stop(), context.Canceled)
+	suite.Empty(suite.deadLetters)
+}
+
+func (suite *QueueSuite) TestExhausted() {
+	q := suite.open(retry.Config{Interval: time.Minute, MaxRetries: 1})
+	defer q.Close()
+	stop := suite.run(q)
+
+	id, err := q.Enqueue([]byte("hello"))
+	suite.Require().NoError(err)
+	suite.attempt(suite.retryErr)
+	suite.clock.BlockUntil(1)
+	suite.clock.Advance(time.Minute)
+	suite.attempt(suite.retryErr)
+
+	suite.waitForLen(q, 0)
+	suite.ErrorIs(stop(), context.Canceled)
+	suite.Require().Len(suite.deadLetters, 1)
+	suite.Equal(id, suite.deadLetters[0].task.ID)
+	suite.Equal(2, suite.deadLetters[0].task.Attempts)
+	suite.ErrorIs(suite.deadLetters[0].err, suite.retryErr)
+}
+
+func (suite *QueueSuite) TestNotRetryable() {
+	q := suite.open(retry.Config{Interval: time.Minute})
+	defer q.Close()
+	stop := suite.run(q)
+
+	permanent := retry.SetRetryable(suite.retryErr, false)
+	_, err := q.Enqueue([]byte("hello"))
+	suite.Require().NoError(err)
+	suite.attempt(permanent)
+
+	suite.waitForLen(q, 0)
+	suite.ErrorIs(stop(), context.Canceled)
+	suite.Require().Len(suite.deadLetters, 1)
+	suite.Equal(1, suite.deadLetters[0].task.Attempts)
+	suite.ErrorIs(suite.deadLetters[0].err, permanent)
+}
+
+func (suite *QueueSuite) TestRestart() {
+	q := suite.open(retry.Config{Interval: time.Minute})
+	stop := suite.run(q)
+
+	id, err := q.Enqueue([]byte("hello"))
+	suite.Require().NoError(err)
+	suite.attempt(suite.retryErr)
+	suite.clock.BlockUntil(1)
+	suite.ErrorIs(stop(), context.Canceled)
+	suite.Require().NoError(q.Close())
+
+	// the pending task, with its attempt count and schedule, survives a restart
+	q = suite.open(retry.Config{Interval: time.Minute})
+	defer q.Close()
+	suite.Equal(1, q.Len())
+
+	stop = suite.run(q)
+	suite.clock.BlockUntil(1)
+	suite.clock.Advance(time.Minute)
+
+	t := suite.attempt(nil)
+	suite.Equal(id, t.ID)
+	suite.Equal(1, t.Attempts)
+	suite.Equal([]byte("hello"), t.Payload)
+
+	suite.waitForLen(q, 0)
+	suite.ErrorIs(stop(), context.Canceled)
+}
+
+func (suite *QueueSuite) TestStopWhileAttempting() {
+	q := suite.open(retry.Config{Interval: time.Minute})
+	stop := suite.run(q)
+
+	id, err := q.Enqueue([]byte("hello"))
+	suite.Require().NoError(err)
+	<-suite.attempts
+
+	// the handler returns the context's error, which must not count as an attempt
+	suite.ErrorIs(stop(), context.Canceled)
+	suite.Require().NoError(q.Close())
+
+	q = suite.open(retry.Config{Interval: time.Minute})
+	defer q.Close()
+	stop = suite.run(q)
+
+	t := suite.attempt(nil)
+	suite.Equal(id, t.ID)
+	suite.Zero(t.Attempts)
+	suite.waitForLen(q, 0)
+	suite.ErrorIs(stop(), context.Canceled)
+}
+
+func (suite *QueueSuite) TestCompaction() {
+	q := suite.open(retry.Config{})
+	ids := make([]string, 0, minCompactRecords)
+	for range minCompactRecords / 2 {
+		id, err := q.Enqueue([]byte("hello"))
+		suite.Require().NoError(err)
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids[:len(ids)-10] {
+		suite.Require().NoError(q.remove(id))
+	}
+
+	suite.Equal(10, q.Len())
+	suite.Less(q.journal.records, minCompactRecords, "the journal must have been compacted")
+	suite.Require().NoError(q.Close())
+
+	q = suite.open(retry.Config{})
+	defer q.Close()
+	suite.Equal(10, q.Len())
+	suite.Equal(10, q.journal.records)
+}
+
+func (suite *QueueSuite) TestClosed() {
+	q := suite.open(retry.Config{})
+	suite.Require().NoError(q.Close())
+	suite.ErrorIs(q.Close(), ErrClosed)
+
+	_, err := q.Enqueue([]byte("hello"))
+	suite.ErrorIs(err, ErrClosed)
+}
+
+func (suite *QueueSuite) TestOpenCorrupt() {
+	suite.Require().NoError(writeFile(suite.path, "garbage\n{}\n"))
+	q, err := Open(suite.path, suite.handler)
+	suite.ErrorIs(err, ErrCorruptJournal)
+	suite.Nil(q)
+}
+
+func TestQueue(t *testing.T) {
+	suite.Run(t, new(QueueSuite))
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"context"
+	"time"
+
+	"github.com/xmidt-org/retry"
+)
+
+// nextAttempt determines when a failed task will be attempted again, or returns false if
+// the policy is exhausted.  Since a Policy cannot be stored, a new one is created and
+// advanced past the task's earlier retries.  This reproduces the interval growth of an
+// exponential policy, though any jitter is chosen anew.
+//
+// The policy's MaxElapsedTime is measured from the time the task was created.
+func nextAttempt(cfg retry.Config, t Task, now time.Time) (time.Time, bool) {
+	maxElapsed := cfg.MaxElapsedTime
+	cfg.MaxElapsedTime = 0
+
+	p := cfg.NewPolicy(context.Background())
+	defer p.Cancel()
+
+	var (
+		interval time.Duration
+		ok       bool
+	)
+
+	// a task with n attempts is waiting for its nth retry
+	for range max(t.Attempts, 1) {
+		if interval, ok = p.Next(); !ok {
+			return time.Time{}, false
+		}
+	}
+
+	next := now.Add(interval)
+	if maxElapsed > 0 && !next.Before(t.Created.Add(maxElapsed)) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+type ScheduleSuite struct {
+	suite.Suite
+
+	now time.Time
+}
+
+func (suite *ScheduleSuite) SetupTest() {
+	suite.now = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+}
+
+// assertSchedule verifies the interval before each retry of a task created at suite.now.
+func (suite *ScheduleSuite) assertSchedule(cfg retry.Config, expected ...time.Duration) {
+	t := Task{Created: suite.now}
+	for i, e := range expected {
+		t.Attempts = i + 1
+		next, ok := nextAttempt(cfg, t, suite.now)
+		suite.Require().True(ok, "retry %d", i)
+		suite.Equal(suite.now.Add(e), next, "retry %d", i)
+	}
+
+	t.Attempts = len(expected) + 1
+	_, ok := nextAttempt(cfg, t, suite.now)
+	suite.False(ok, "the policy must be exhausted")
+}
+
+func (suite *ScheduleSuite) TestNever() {
+	suite.assertSchedule(retry.Config{})
+}
+
+func (suite *ScheduleSuite) TestConstant() {
+	suite.assertSchedule(
+		retry.Config{Interval: time.Minute, MaxRetries: 3},
+		time.Minute, time.Minute, time.Minute,
+	)
+}
+
+func (suite *ScheduleSuite) TestExponential() {
+	suite.assertSchedule(
+		retry.Config{Interval: time.Second, Multiplier: 2.0, MaxInterval: 5 * time.Second, MaxRetries: 4},
+		time.Second, 2*time.Second, 4*time.Second, 5*time.Second,
+	)
+}
+
+func (suite *ScheduleSuite) TestMaxElapsedTime() {
+	cfg := retry.Config{Interval: time.Minute, MaxElapsedTime: 10 * time.Minute}
+	t := Task{Created: suite.now, Attempts: 5}
+
+	next, ok := nextAttempt(cfg, t, suite.now.Add(8*time.Minute))
+	suite.True(ok)
+	suite.Equal(suite.now.Add(9*time.Minute), next)
+
+	_, ok = nextAttempt(cfg, t, suite.now.Add(9*time.Minute))
+	suite.False(ok, "a retry at the deadline must not be scheduled")
+}
+
+func TestSchedule(t *testing.T) {
+	suite.Run(t, new(ScheduleSuite))
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retryqueue
+
+import (
+	"context"
+	"time"
+)
+
+// Task is a unit of work stored in a Queue.
+type Task struct {
+	// ID uniquely identifies this task within its Queue.
+	ID string `json:"id" yaml:"id"`
+
+	// Payload is the serialized work, which only the Handler interprets.
+	Payload []byte `json:"payload" yaml:"payload"`
+
+	// Attempts is the number of attempts made so far.  It is zero for a task that
+	// has never been attempted.
+	Attempts int `json:"attempts" yaml:"attempts"`
+
+	// Created is the time this task was enqueued.  The policy's MaxElapsedTime is
+	// measured from this time.
+	Created time.Time `json:"created" yaml:"created"`
+
+	// NextAttempt is the earliest time at which this task will be attempted.
+	NextAttempt time.Time `json:"nextAttempt" yaml:"nextAttempt"`
+
+	// LastError is the message of the error returned by the last attempt, if any.
+	LastError string `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+}
+
+// Handler performs a Task.  A nil error indicates success, and the task is removed
+// from the Queue.  Otherwise, retry.DefaultTestErrorForRetry determines whether the
+// task is retried, so errors created with retry.SetRetryable are honored.
+//
+// The context is canceled when the Queue stops running.
+type Handler func(ctx context.Context, t Task) error
+
+// DeadLetter receives each Task that is removed from a Queue without succeeding, along with
+// the error from its last attempt.  The Task's Attempts field includes that last attempt.
+type DeadLetter func(ctx context.Context, t Task, err error)
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// AssertAttempts checks that the Recorder has seen exactly the expected number of
+// attempts.  If not, the test is marked as failed.  This function returns true if
+// the assertion passed.
+func AssertAttempts[V any](t testing.TB, r *Recorder[V], expected int) bool {
+	t.Helper()
+	if actual := r.Len(); actual != expected {
+		t.Errorf("expected %d attempts, but there were %d", expected, actual)
+		return false
+	}
+
+	return true
+}
+
+// AssertSchedule checks that the Recorder's retry intervals, as returned by
+// Recorder.Schedule, are exactly the expected intervals.  If not, the test is marked
+// as failed.  This function returns true if the assertion passed.
+func AssertSchedule[V any](t testing.TB, r *Recorder[V], expected ...time.Duration) bool {
+	t.Helper()
+	if actual := r.Schedule(); !slices.Equal(actual, expected) {
+		t.Errorf("expected the retry schedule %v, but it was %v", expected, actual)
+		return false
+	}
+
+	return true
+}
+
+// AssertElapsed checks that the Elapsed field of each attempt the Recorder has seen is
+// exactly the corresponding expected duration.  If not, the test is marked as failed.
+// This function returns true if the assertion passed.
+//
+// This assertion is only useful when time is deterministic, such as inside a
+// testing/synctest bubble or when the Runner uses a FakeClock.
+func AssertElapsed[V any](t testing.TB, r *Recorder[V], expected ...time.Duration) bool {
+	t.Helper()
+	if actual := r.Elapsed(); !slices.Equal(actual, expected) {
+		t.Errorf("expected the elapsed times %v, but they were %v", expected, actual)
+		return false
+	}
+
+	return true
+}
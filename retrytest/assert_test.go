@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+// testTB captures assertion failures instead of failing the enclosing test.
+type testTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *testTB) Helper() {}
+
+func (tb *testTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+type AssertSuite struct {
+	suite.Suite
+
+	tb *testTB
+	r  *Recorder[int]
+}
+
+func (suite *AssertSuite) SetupTest() {
+	suite.tb = &testTB{TB: suite.T()}
+	suite.r = new(Recorder[int])
+	suite.r.OnAttempt(retry.Attempt[int]{Next: time.Second})
+	suite.r.OnAttempt(retry.Attempt[int]{Next: 2 * time.Second})
+	suite.r.OnAttempt(retry.Attempt[int]{})
+}
+
+func (suite *AssertSuite) TestAssertAttempts() {
+	suite.True(AssertAttempts(suite.tb, suite.r, 3))
+	suite.Empty(suite.tb.errors)
+
+	suite.False(AssertAttempts(suite.tb, suite.r, 2))
+	suite.Len(suite.tb.errors, 1)
+}
+
+func (suite *AssertSuite) TestAssertSchedule() {
+	suite.True(AssertSchedule(suite.tb, suite.r, time.Second, 2*time.Second))
+	suite.Empty(suite.tb.errors)
+
+	suite.False(AssertSchedule(suite.tb, suite.r, time.Second))
+	suite.Len(suite.tb.errors, 1)
+
+	suite.True(AssertSchedule(suite.tb, new(Recorder[int])))
+	suite.Len(suite.tb.errors, 1)
+}
+
+func (suite *AssertSuite) TestAssertElapsed() {
+	r := new(Recorder[int])
+	r.OnAttempt(retry.Attempt[int]{})
+	r.OnAttempt(retry.Attempt[int]{Elapsed: time.Second})
+
+	suite.True(AssertElapsed(suite.tb, r, 0, time.Second))
+	suite.Empty(suite.tb.errors)
+
+	suite.False(AssertElapsed(suite.tb, r, 0))
+	suite.Len(suite.tb.errors, 1)
+}
+
+func TestAssert(t *testing.T) {
+	suite.Run(t, new(AssertSuite))
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package retrytest provides utilities for testing code that uses retries.
+
+Scripted tasks, such as FailN and Sequence, produce predetermined results.  A
+ScriptedPolicy produces a predetermined retry schedule.  A Recorder captures each
+attempt, and AssertAttempts and AssertSchedule verify what was recorded:
+
+	var r retrytest.Recorder[int]
+	runner, _ := retry.NewRunner(
+		retry.WithImmediateTimer[int](),
+		retry.WithOnAttempt(r.OnAttempt),
+		retry.WithPolicyFactory[int](
+			retrytest.ScriptedPolicy{time.Second, 2 * time.Second},
+		),
+	)
+
+	result, err := runner.Run(ctx, retrytest.FailN(2, errors.New("expected"), 123))
+	retrytest.AssertAttempts(t, &r, 3)
+	retrytest.AssertSchedule(t, &r, time.Second, 2*time.Second)
+
+A FakeClock allows retry schedules, including elapsed time limits, to be tested
+instantly and deterministically:
+
+	clock := retrytest.NewFakeClock(time.Now())
+	runner, _ := retry.NewRunner(
+		retry.WithClock[int](clock),
+		retry.WithPolicyFactory[int](
+			retry.Config{
+				Interval:       time.Minute,
+				MaxElapsedTime: 10 * time.Minute,
+			},
+		),
+	)
+
+	f := runner.RunAsync(ctx, task)
+	for i := 0; i < 10; i++ {
+		clock.BlockUntil(2) // the retry timer and the MaxElapsedTime deadline
+		clock.Advance(time.Minute)
+	}
+
+	_, err := f.Wait(ctx) // context.DeadlineExceeded, after 10 virtual minutes
+
+Runners that use the default retry.SystemClock also work inside a testing/synctest
+bubble, where the time package itself is fake.  This allows real Config values to be
+tested end to end, and AssertElapsed can verify exactly when each attempt happened:
+
+	synctest.Test(t, func(t *testing.T) {
+		var r retrytest.Recorder[int]
+		runner, _ := retry.NewRunner(
+			retry.WithOnAttempt(r.OnAttempt),
+			retry.WithPolicyFactory[int](retry.Config{Interval: time.Minute}),
+		)
+
+		runner.Run(ctx, retrytest.FailN(2, errors.New("expected"), 123))
+		retrytest.AssertElapsed(t, &r, 0, time.Minute, 2*time.Minute)
+	})
+*/
+package retrytest
@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// fakeWaiter is anything waiting on a FakeClock, either a timer or a context deadline.
+type fakeWaiter struct {
+	when time.Time
+	fire func(time.Time)
+}
+
+// FakeClock is a retry.Clock whose time only moves when Advance is called.  Timers and
+// context deadlines created by a FakeClock fire when the clock is advanced to or beyond
+// their scheduled time.  A FakeClock is safe for concurrent use.
+type FakeClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+
+	// changed is closed and replaced each time a waiter is added
+	changed chan struct{}
+}
+
+// NewFakeClock creates a FakeClock whose current time is start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{
+		now:     start,
+		changed: make(chan struct{}),
+	}
+}
+
+// Now returns this clock's current time.
+func (fc *FakeClock) Now() time.Time {
+	defer fc.lock.Unlock()
+	fc.lock.Lock()
+	return fc.now
+}
+
+// Waiters returns the number of timers and context deadlines that have not yet fired
+// and have not been stopped or canceled.
+func (fc *FakeClock) Waiters() int {
+	defer fc.lock.Unlock()
+	fc.lock.Lock()
+	return len(fc.waiters)
+}
+
+// BlockUntil blocks until this clock has at least n waiters.  Tests typically use this
+// method to wait for the code under test to start a timer before calling Advance.
+func (fc *FakeClock) BlockUntil(n int) {
+	for {
+		fc.lock.Lock()
+		count, changed := len(fc.waiters), fc.changed
+		fc.lock.Unlock()
+
+		if count >= n {
+			return
+		}
+
+		<-changed
+	}
+}
+
+// Advance moves this clock forward by d, firing any timers and context deadlines that
+// are due in the order they were scheduled.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.lock.Lock()
+	fc.now = fc.now.Add(d)
+	now := fc.now
+
+	var due []*fakeWaiter
+	fc.waiters = slices.DeleteFunc(fc.waiters, func(w *fakeWaiter) bool {
+		if w.when.After(now) {
+			return false
+		}
+
+		due = append(due, w)
+		return true
+	})
+
+	fc.lock.Unlock()
+
+	slices.SortStableFunc(due, func(a, b *fakeWaiter) int {
+		return a.when.Compare(b.when)
+	})
+
+	for _, w := range due {
+		w.fire(now)
+	}
+}
+
+// add registers a waiter.  If the waiter is already due, it is fired immediately and
+// false is returned.
+func (fc *FakeClock) add(w *fakeWaiter) bool {
+	fc.lock.Lock()
+	now := fc.now
+	if w.when.After(now) {
+		fc.waiters = append(fc.waiters, w)
+		close(fc.changed)
+		fc.changed = make(chan struct{})
+		fc.lock.Unlock()
+		return true
+	}
+
+	fc.lock.Unlock()
+	w.fire(now)
+	return false
+}
+
+// remove deregisters a waiter, returning true if the waiter had not yet fired.
+func (fc *FakeClock) remove(w *fakeWaiter) bool {
+	defer fc.lock.Unlock()
+	fc.lock.Lock()
+	i := slices.Index(fc.waiters, w)
+	if i >= 0 {
+		fc.waiters = slices.Delete(fc.waiters, i, i+1)
+	}
+
+	return i >= 0
+}
+
+// NewTimer starts a timer that fires when this clock is advanced by at least d.
+// A nonpositive d fires immediately.  The returned stop function has the same
+// semantics as time.Timer.Stop.
+func (fc *FakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	ch := make(chan time.Time, 1)
+	w := &fakeWaiter{
+		when: fc.Now().Add(d),
+		fire: func(t time.Time) { ch <- t },
+	}
+
+	fc.add(w)
+	return ch, func() bool { return fc.remove(w) }
+}
+
+// WithDeadline creates a child context that is done when this clock is advanced to
+// or beyond the deadline, at which point its Err method returns context.DeadlineExceeded.
+// The child is also done when the parent is done or when the returned cancel function
+// is called.
+func (fc *FakeClock) WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if current, ok := parent.Deadline(); ok && current.Before(deadline) {
+		// same as the stdlib: the parent's deadline will happen first
+		return context.WithCancel(parent)
+	}
+
+	ctx := &fakeDeadlineCtx{
+		parent:   parent,
+		deadline: deadline,
+		done:     make(chan struct{}),
+	}
+
+	w := &fakeWaiter{
+		when: deadline,
+		fire: func(time.Time) { ctx.cancel(context.DeadlineExceeded) },
+	}
+
+	stop := context.AfterFunc(parent, func() {
+		fc.remove(w)
+		ctx.cancel(parent.Err())
+	})
+
+	fc.add(w)
+	return ctx, func() {
+		stop()
+		fc.remove(w)
+		ctx.cancel(context.Canceled)
+	}
+}
+
+// fakeDeadlineCtx is a context whose deadline is driven by a FakeClock.
+type fakeDeadlineCtx struct {
+	parent   context.Context
+	deadline time.Time
+	done     chan struct{}
+
+	lock sync.Mutex
+	err  error
+}
+
+func (ctx *fakeDeadlineCtx) Deadline() (time.Time, bool) { return ctx.deadline, true }
+func (ctx *fakeDeadlineCtx) Done() <-chan struct{}       { return ctx.done }
+func (ctx *fakeDeadlineCtx) Value(key any) any           { return ctx.parent.Value(key) }
+
+func (ctx *fakeDeadlineCtx) Err() error {
+	defer ctx.lock.Unlock()
+	ctx.lock.Lock()
+	return ctx.err
+}
+
+// cancel marks this context as done.  Only the first call has any effect.
+func (ctx *fakeDeadlineCtx) cancel(err error) {
+	defer ctx.lock.Unlock()
+	ctx.lock.Lock()
+	if ctx.err == nil {
+		ctx.err = err
+		close(ctx.done)
+	}
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+type FakeClockSuite struct {
+	suite.Suite
+
+	start time.Time
+	clock *FakeClock
+}
+
+var _ retry.Clock = (*FakeClock)(nil)
+
+func (suite *FakeClockSuite) SetupTest() {
+	suite.start = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	suite.clock = NewFakeClock(suite.start)
+}
+
+func (suite *FakeClockSuite) assertFired(ch <-chan time.Time, expected time.Time) {
+	select {
+	case actual := <-ch:
+		suite.Equal(expected, actual)
+	default:
+		suite.Fail("the timer did not fire")
+	}
+}
+
+func (suite *FakeClockSuite) assertNotFired(ch <-chan time.Time) {
+	select {
+	case <-ch:
+		suite.Fail("the timer should not have fired")
+	default:
+		// passing
+	}
+}
+
+func (suite *FakeClockSuite) TestNow() {
+	suite.Equal(suite.start, suite.clock.Now())
+	suite.clock.Advance(time.Hour)
+	suite.Equal(suite.start.Add(time.Hour), suite.clock.Now())
+}
+
+func (suite *FakeClockSuite) TestTimer() {
+	ch, stop := suite.clock.NewTimer(time.Minute)
+	suite.Equal(1, suite.clock.Waiters())
+
+	suite.clock.Advance(time.Minute - time.Second)
+	suite.assertNotFired(ch)
+
+	suite.clock.Advance(2 * time.Second)
+	suite.assertFired(ch, suite.start.Add(time.Minute+time.Second))
+	suite.Zero(suite.clock.Waiters())
+	suite.False(stop())
+}
+
+func (suite *FakeClockSuite) TestTimerStop() {
+	ch, stop := suite.clock.NewTimer(time.Minute)
+	suite.True(stop())
+	suite.False(stop())
+	suite.Zero(suite.clock.Waiters())
+
+	suite.clock.Advance(time.Hour)
+	suite.assertNotFired(ch)
+}
+
+func (suite *FakeClockSuite) TestTimerNonpositive() {
+	ch, stop := suite.clock.NewTimer(0)
+	suite.assertFired(ch, suite.start)
+	suite.Zero(suite.clock.Waiters())
+	suite.False(stop())
+}
+
+func (suite *FakeClockSuite) TestBlockUntil() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		suite.clock.BlockUntil(2)
+	}()
+
+	suite.clock.NewTimer(time.Minute)
+	select {
+	case <-done:
+		suite.Fail("BlockUntil should still be waiting")
+	case <-time.After(10 * time.Millisecond):
+		// passing
+	}
+
+	suite.clock.NewTimer(time.Minute)
+	select {
+	case <-done:
+		// passing
+	case <-time.After(time.Second):
+		suite.Fail("BlockUntil did not return")
+	}
+}
+
+func (suite *FakeClockSuite) TestWithDeadline() {
+	ctx, cancel := suite.clock.WithDeadline(context.Background(), suite.start.Add(time.Minute))
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	suite.True(ok)
+	suite.Equal(suite.start.Add(time.Minute), deadline)
+	suite.NoError(ctx.Err())
+
+	suite.clock.Advance(time.Minute)
+	suite.Require().ErrorIs(ctx.Err(), context.DeadlineExceeded)
+	<-ctx.Done()
+	suite.Zero(suite.clock.Waiters())
+}
+
+func (suite *FakeClockSuite) TestWithDeadlineCanceled() {
+	ctx, cancel := suite.clock.WithDeadline(context.Background(), suite.start.Add(time.Minute))
+	cancel()
+	suite.ErrorIs(ctx.Err(), context.Canceled)
+	suite.Zero(suite.clock.Waiters())
+
+	suite.clock.Advance(time.Hour)
+	suite.ErrorIs(ctx.Err(), context.Canceled)
+}
+
+func (suite *FakeClockSuite) TestWithDeadlineParentCanceled() {
+	parent, parentCancel := context.WithCancel(context.Background())
+	ctx, cancel := suite.clock.WithDeadline(parent, suite.start.Add(time.Minute))
+	defer cancel()
+
+	parentCancel()
+	<-ctx.Done()
+	suite.ErrorIs(ctx.Err(), context.Canceled)
+	suite.Eventually(
+		func() bool { return suite.clock.Waiters() == 0 },
+		time.Second,
+		time.Millisecond,
+	)
+}
+
+func (suite *FakeClockSuite) TestWithDeadlineAlreadyPassed() {
+	ctx, cancel := suite.clock.WithDeadline(context.Background(), suite.start)
+	defer cancel()
+	suite.ErrorIs(ctx.Err(), context.DeadlineExceeded)
+	suite.Zero(suite.clock.Waiters())
+}
+
+func (suite *FakeClockSuite) TestWithDeadlineParentSooner() {
+	parent, parentCancel := suite.clock.WithDeadline(context.Background(), suite.start.Add(time.Second))
+	defer parentCancel()
+
+	ctx, cancel := suite.clock.WithDeadline(parent, suite.start.Add(time.Minute))
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	suite.Equal(suite.start.Add(time.Second), deadline)
+	suite.Equal(1, suite.clock.Waiters())
+}
+
+func (suite *FakeClockSuite) TestRunnerMaxElapsedTime() {
+	var (
+		taskErr = errors.New("expected")
+		elapsed []time.Duration
+
+		runner, err = retry.NewRunner(
+			retry.WithClock[int](suite.clock),
+			retry.WithPolicyFactory[int](retry.Config{
+				Interval:       time.Minute,
+				MaxElapsedTime: 5 * time.Minute,
+			}),
+			retry.WithOnAttempt(func(a retry.Attempt[int]) {
+				elapsed = append(elapsed, a.Elapsed)
+			}),
+		)
+	)
+
+	suite.Require().NoError(err)
+	f := runner.RunAsync(context.Background(), func(ctx context.Context) (int, error) {
+		suite.Same(suite.clock, retry.ClockFromContext(ctx))
+		return -1, taskErr
+	})
+
+	for i := 0; i < 5; i++ {
+		// the retry timer and the MaxElapsedTime deadline
+		suite.clock.BlockUntil(2)
+		suite.clock.Advance(time.Minute)
+	}
+
+	_, err = f.Wait(context.Background())
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Equal(
+		[]time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute},
+		elapsed,
+	)
+}
+
+func TestFakeClock(t *testing.T) {
+	suite.Run(t, new(FakeClockSuite))
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/xmidt-org/retry"
+)
+
+// Recorder keeps track of the attempts made by a Runner.  Its OnAttempt method
+// is used as the callback:
+//
+//	var r retrytest.Recorder[int]
+//	runner, _ := retry.NewRunner(retry.WithOnAttempt(r.OnAttempt))
+//
+// The zero value of this type is ready to use.  A Recorder is safe for concurrent use.
+type Recorder[V any] struct {
+	lock     sync.Mutex
+	attempts []retry.Attempt[V]
+}
+
+// OnAttempt records the given Attempt.  This method may be passed to retry.WithOnAttempt.
+func (r *Recorder[V]) OnAttempt(a retry.Attempt[V]) {
+	defer r.lock.Unlock()
+	r.lock.Lock()
+	r.attempts = append(r.attempts, a)
+}
+
+// Attempts returns a copy of the attempts recorded so far, in the order they happened.
+func (r *Recorder[V]) Attempts() []retry.Attempt[V] {
+	defer r.lock.Unlock()
+	r.lock.Lock()
+	return slices.Clone(r.attempts)
+}
+
+// Len returns the number of attempts recorded so far.
+func (r *Recorder[V]) Len() int {
+	defer r.lock.Unlock()
+	r.lock.Lock()
+	return len(r.attempts)
+}
+
+// Schedule returns the retry intervals that have been recorded, i.e. the Next field of each
+// attempt that was followed by a retry.
+func (r *Recorder[V]) Schedule() (s []time.Duration) {
+	defer r.lock.Unlock()
+	r.lock.Lock()
+	for _, a := range r.attempts {
+		if a.Next > 0 {
+			s = append(s, a.Next)
+		}
+	}
+
+	return
+}
+
+// Elapsed returns the Elapsed field of each recorded attempt, in order.  When time is
+// deterministic, e.g. inside a testing/synctest bubble or with a FakeClock, these values
+// are exact.
+func (r *Recorder[V]) Elapsed() (e []time.Duration) {
+	defer r.lock.Unlock()
+	r.lock.Lock()
+	for _, a := range r.attempts {
+		e = append(e, a.Elapsed)
+	}
+
+	return
+}
+
+// Reset discards all the recorded attempts.
+func (r *Recorder[V]) Reset() {
+	defer r.lock.Unlock()
+	r.lock.Lock()
+	r.attempts = nil
+}
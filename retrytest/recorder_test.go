@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+type RecorderSuite struct {
+	suite.Suite
+}
+
+func (suite *RecorderSuite) TestZeroValue() {
+	var r Recorder[int]
+	suite.Zero(r.Len())
+	suite.Empty(r.Attempts())
+	suite.Empty(r.Schedule())
+}
+
+func (suite *RecorderSuite) TestRunner() {
+	var (
+		r           Recorder[int]
+		expectedErr = errors.New("expected")
+
+		runner, err = retry.NewRunner(
+			retry.WithImmediateTimer[int](),
+			retry.WithOnAttempt(r.OnAttempt),
+			retry.WithPolicyFactory[int](
+				ScriptedPolicy{time.Second, 2 * time.Second, 3 * time.Second},
+			),
+		)
+	)
+
+	suite.Require().NoError(err)
+	result, err := runner.Run(context.Background(), FailN(2, expectedErr, 123))
+	suite.Equal(123, result)
+	suite.NoError(err)
+
+	suite.Equal(3, r.Len())
+	suite.Equal([]time.Duration{time.Second, 2 * time.Second}, r.Schedule())
+
+	attempts := r.Attempts()
+	suite.Require().Len(attempts, 3)
+	suite.Same(expectedErr, attempts[0].Err)
+	suite.Same(expectedErr, attempts[1].Err)
+	suite.Equal(123, attempts[2].Result)
+	suite.True(attempts[2].Done())
+
+	r.Reset()
+	suite.Zero(r.Len())
+}
+
+func TestRecorder(t *testing.T) {
+	suite.Run(t, new(RecorderSuite))
+}
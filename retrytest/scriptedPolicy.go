@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/xmidt-org/retry"
+)
+
+// ScriptedPolicy is a retry.PolicyFactory whose policies return the given intervals,
+// in order, and then stop retrying.  This allows tests to control the retry schedule
+// exactly:
+//
+//	runner, _ := retry.NewRunner(
+//		retry.WithPolicyFactory[int](
+//			retrytest.ScriptedPolicy{time.Second, 5 * time.Second},
+//		),
+//	)
+type ScriptedPolicy []time.Duration
+
+// NewPolicy creates a retry.Policy that uses these intervals.  Each policy has its own
+// position in the script, so that the same ScriptedPolicy may be used for many tasks.
+func (sp ScriptedPolicy) NewPolicy(parentCtx context.Context) retry.Policy {
+	ctx, cancel := context.WithCancel(parentCtx)
+	return &scriptedPolicy{
+		ctx:       ctx,
+		cancel:    cancel,
+		intervals: sp,
+	}
+}
+
+type scriptedPolicy struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	intervals []time.Duration
+	next      int
+}
+
+func (sp *scriptedPolicy) Context() context.Context {
+	return sp.ctx
+}
+
+func (sp *scriptedPolicy) Cancel() {
+	if sp.cancel != nil {
+		sp.cancel()
+		sp.cancel = nil
+	}
+}
+
+func (sp *scriptedPolicy) Next() (time.Duration, bool) {
+	if sp.cancel == nil || sp.ctx.Err() != nil || sp.next >= len(sp.intervals) {
+		return 0, false
+	}
+
+	d := sp.intervals[sp.next]
+	sp.next++
+	return d, true
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ScriptedPolicySuite struct {
+	suite.Suite
+}
+
+func (suite *ScriptedPolicySuite) TestNext() {
+	sp := ScriptedPolicy{time.Second, 5 * time.Second}
+	for i := 0; i < 2; i++ {
+		p := sp.NewPolicy(context.Background())
+		suite.Require().NotNil(p)
+		suite.NotNil(p.Context())
+
+		d, ok := p.Next()
+		suite.True(ok)
+		suite.Equal(time.Second, d)
+
+		d, ok = p.Next()
+		suite.True(ok)
+		suite.Equal(5*time.Second, d)
+
+		d, ok = p.Next()
+		suite.False(ok)
+		suite.Zero(d)
+
+		p.Cancel()
+	}
+}
+
+func (suite *ScriptedPolicySuite) TestCancel() {
+	p := ScriptedPolicy{time.Second}.NewPolicy(context.Background())
+	p.Cancel()
+	p.Cancel() // idempotent
+	suite.ErrorIs(p.Context().Err(), context.Canceled)
+
+	d, ok := p.Next()
+	suite.False(ok)
+	suite.Zero(d)
+}
+
+func (suite *ScriptedPolicySuite) TestParentCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := ScriptedPolicy{time.Second}.NewPolicy(ctx)
+	defer p.Cancel()
+
+	cancel()
+	d, ok := p.Next()
+	suite.False(ok)
+	suite.Zero(d)
+}
+
+func TestScriptedPolicy(t *testing.T) {
+	suite.Run(t, new(ScriptedPolicySuite))
+}
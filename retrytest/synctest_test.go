@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build go1.25
+
+package retrytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/xmidt-org/retry"
+)
+
+func TestSynctestRecorder(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		var (
+			r           Recorder[int]
+			expectedErr = errors.New("expected")
+
+			runner, err = retry.NewRunner(
+				retry.WithOnAttempt(r.OnAttempt),
+				retry.WithPolicyFactory[int](
+					ScriptedPolicy{time.Minute, 5 * time.Minute},
+				),
+			)
+		)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := runner.Run(context.Background(), FailN(2, expectedErr, 123))
+		if result != 123 || err != nil {
+			t.Errorf("unexpected result: %d, %v", result, err)
+		}
+
+		AssertAttempts(t, &r, 3)
+		AssertSchedule(t, &r, time.Minute, 5*time.Minute)
+		AssertElapsed(t, &r, 0, time.Minute, 6*time.Minute)
+	})
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/xmidt-org/retry"
+)
+
+// ErrSequenceExhausted is returned by a Sequence task once all of its results have
+// been used.  This error is not retryable.
+var ErrSequenceExhausted = errors.New("the task sequence has no more results")
+
+// Result is a scripted outcome for a single task attempt.
+type Result[V any] struct {
+	// Value is the value the task returns.
+	Value V
+
+	// Err is the error the task returns.
+	Err error
+}
+
+// Succeed is a convenience for creating a successful Result.
+func Succeed[V any](v V) Result[V] {
+	return Result[V]{Value: v}
+}
+
+// Fail is a convenience for creating a failed Result with the zero value for V.
+func Fail[V any](err error) Result[V] {
+	return Result[V]{Err: err}
+}
+
+// FailN creates a task that fails with err for its first n attempts, then succeeds
+// with the then value for all subsequent attempts.  The returned task is safe for
+// concurrent use.
+func FailN[V any](n int, err error, then V) retry.Task[V] {
+	var (
+		lock  sync.Mutex
+		calls int
+	)
+
+	return func(context.Context) (v V, taskErr error) {
+		lock.Lock()
+		calls++
+		failed := calls <= n
+		lock.Unlock()
+
+		if failed {
+			taskErr = err
+		} else {
+			v = then
+		}
+
+		return
+	}
+}
+
+// Sequence creates a task that returns each of the given results in order, one per
+// attempt.  Once the results are exhausted, the task fails with ErrSequenceExhausted.
+// The returned task is safe for concurrent use.
+func Sequence[V any](results ...Result[V]) retry.Task[V] {
+	var (
+		lock sync.Mutex
+		next int
+	)
+
+	results = append([]Result[V](nil), results...)
+	return func(context.Context) (v V, err error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if next >= len(results) {
+			err = retry.SetRetryable(ErrSequenceExhausted, false)
+			return
+		}
+
+		v, err = results[next].Value, results[next].Err
+		next++
+		return
+	}
+}
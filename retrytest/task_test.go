@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retrytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xmidt-org/retry"
+)
+
+type TaskSuite struct {
+	suite.Suite
+}
+
+func (suite *TaskSuite) TestFailN() {
+	var (
+		expectedErr = errors.New("expected")
+		task        = FailN(2, expectedErr, 123)
+	)
+
+	for i := 0; i < 2; i++ {
+		v, err := task(context.Background())
+		suite.Zero(v)
+		suite.Same(expectedErr, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		v, err := task(context.Background())
+		suite.Equal(123, v)
+		suite.NoError(err)
+	}
+}
+
+func (suite *TaskSuite) TestFailNZero() {
+	v, err := FailN(0, errors.New("unused"), "success")(context.Background())
+	suite.Equal("success", v)
+	suite.NoError(err)
+}
+
+func (suite *TaskSuite) TestSequence() {
+	var (
+		expectedErr = errors.New("expected")
+		task        = Sequence(
+			Fail[int](expectedErr),
+			Result[int]{Value: -1, Err: expectedErr},
+			Succeed(123),
+		)
+	)
+
+	v, err := task(context.Background())
+	suite.Zero(v)
+	suite.Same(expectedErr, err)
+
+	v, err = task(context.Background())
+	suite.Equal(-1, v)
+	suite.Same(expectedErr, err)
+
+	v, err = task(context.Background())
+	suite.Equal(123, v)
+	suite.NoError(err)
+
+	v, err = task(context.Background())
+	suite.Zero(v)
+	suite.ErrorIs(err, ErrSequenceExhausted)
+	suite.False(retry.DefaultTestErrorForRetry(err))
+}
+
+func (suite *TaskSuite) TestSequenceEmpty() {
+	_, err := Sequence[int]()(context.Background())
+	suite.ErrorIs(err, ErrSequenceExhausted)
+}
+
+func TestTask(t *testing.T) {
+	suite.Run(t, new(TaskSuite))
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotRun is the error reported by RunEach and RunAll for any task that was never
+// scheduled because an earlier task failed.  See WithStopOnFailure.
+var ErrNotRun = errors.New("task not run due to an earlier failure")
+
+// EachOption is a configurable option for RunEach and RunAll.
+type EachOption interface {
+	apply(*eachOptions)
+}
+
+type eachOptionFunc func(*eachOptions)
+
+func (eof eachOptionFunc) apply(o *eachOptions) { eof(o) }
+
+// WithMaxConcurrency limits the number of tasks that RunEach or RunAll will run
+// at the same time.  If this option is not supplied, or if n is nonpositive,
+// all tasks are run concurrently.
+func WithMaxConcurrency(n int) EachOption {
+	return eachOptionFunc(func(o *eachOptions) {
+		o.maxConcurrency = n
+	})
+}
+
+// WithStopOnFailure causes RunEach and RunAll to stop scheduling new tasks once
+// any task fails, i.e. once Runner.Run returns an error after any retries.  Tasks
+// already running are allowed to finish.  Each task that was never scheduled reports
+// ErrNotRun.
+func WithStopOnFailure() EachOption {
+	return eachOptionFunc(func(o *eachOptions) {
+		o.stopOnFailure = true
+	})
+}
+
+type eachOptions struct {
+	maxConcurrency int
+	stopOnFailure  bool
+}
+
+func newEachOptions(taskCount int, opts []EachOption) (o eachOptions) {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+
+	if o.maxConcurrency <= 0 || o.maxConcurrency > taskCount {
+		o.maxConcurrency = taskCount
+	}
+
+	return
+}
+
+// eachOutcome is the result of running a single task via RunEach.
+type eachOutcome[V any] struct {
+	index  int
+	result V
+	err    error
+}
+
+// RunEach runs each task through the given Runner, subject to any EachOptions.  The
+// callback is invoked once for each task with the task's index and the results of
+// Runner.Run, in order of completion.  The callback is always invoked on the goroutine
+// that called this function, so it does not need to be safe for concurrent use.
+//
+// This function blocks until every task has either finished or been abandoned.
+// If the context is canceled, no new tasks are scheduled and each task that was never
+// scheduled reports ctx.Err().
+func RunEach[V any](ctx context.Context, r Runner[V], tasks []Task[V], fn func(int, V, error), opts ...EachOption) {
+	var (
+		o        = newEachOptions(len(tasks), opts)
+		outcomes = make(chan eachOutcome[V], o.maxConcurrency)
+		next     int
+		running  int
+		haltErr  error
+	)
+
+	for haltErr == nil && next < len(tasks) || running > 0 {
+		if haltErr == nil && next < len(tasks) && running < o.maxConcurrency {
+			if haltErr = ctx.Err(); haltErr == nil {
+				go func(i int) {
+					result, err := r.Run(ctx, tasks[i])
+					outcomes <- eachOutcome[V]{index: i, result: result, err: err}
+				}(next)
+
+				next++
+				running++
+			}
+
+			continue
+		}
+
+		oc := <-outcomes
+		running--
+		fn(oc.index, oc.result, oc.err)
+		if oc.err != nil && o.stopOnFailure && haltErr == nil {
+			haltErr = ErrNotRun
+		}
+	}
+
+	var zero V
+	for ; next < len(tasks); next++ {
+		fn(next, zero, haltErr)
+	}
+}
+
+// RunAll runs each task through the given Runner, subject to any EachOptions, and returns
+// the results and errors from Runner.Run in the same order as the tasks.  This function
+// blocks until every task has either finished or been abandoned.
+//
+// See RunEach for details on cancelation and the handling of failures.
+func RunAll[V any](ctx context.Context, r Runner[V], tasks []Task[V], opts ...EachOption) (results []V, errs []error) {
+	results = make([]V, len(tasks))
+	errs = make([]error, len(tasks))
+	RunEach(
+		ctx,
+		r,
+		tasks,
+		func(i int, result V, err error) {
+			results[i] = result
+			errs[i] = err
+		},
+		opts...,
+	)
+
+	return
+}
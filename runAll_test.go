@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RunAllSuite struct {
+	CommonSuite
+}
+
+// newTasks creates count tasks that each return ten times their index.  The
+// concurrency of the tasks is tracked in the returned counter.
+func (suite *RunAllSuite) newTasks(count int, maxRunning *atomic.Int32) []Task[int] {
+	var running atomic.Int32
+	tasks := make([]Task[int], count)
+	for i := range tasks {
+		tasks[i] = func(ctx context.Context) (int, error) {
+			suite.assertTestCtx(ctx)
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := maxRunning.Load()
+				if current <= m || maxRunning.CompareAndSwap(m, current) {
+					break
+				}
+			}
+
+			// stagger completion so that tasks finish out of order
+			time.Sleep(time.Duration(count-i) * time.Millisecond)
+			return i * 10, nil
+		}
+	}
+
+	return tasks
+}
+
+func (suite *RunAllSuite) TestUnbounded() {
+	var (
+		testCtx, _ = suite.testCtx()
+		maxRunning atomic.Int32
+		tasks      = suite.newTasks(5, &maxRunning)
+	)
+
+	results, errs := RunAll(testCtx, suite.newRunner(), tasks)
+	suite.Equal([]int{0, 10, 20, 30, 40}, results)
+	suite.Equal(make([]error, 5), errs)
+	suite.LessOrEqual(maxRunning.Load(), int32(5))
+}
+
+func (suite *RunAllSuite) TestMaxConcurrency() {
+	var (
+		testCtx, _ = suite.testCtx()
+		maxRunning atomic.Int32
+		tasks      = suite.newTasks(6, &maxRunning)
+	)
+
+	results, errs := RunAll(testCtx, suite.newRunner(), tasks, WithMaxConcurrency(2))
+	suite.Equal([]int{0, 10, 20, 30, 40, 50}, results)
+	suite.Equal(make([]error, 6), errs)
+	suite.LessOrEqual(maxRunning.Load(), int32(2))
+}
+
+func (suite *RunAllSuite) TestStopOnFailure() {
+	var (
+		testCtx, _  = suite.testCtx()
+		expectedErr = errors.New("expected")
+		calls       atomic.Int32
+		task        = func(context.Context) (int, error) {
+			if calls.Add(1) == 2 {
+				return -1, expectedErr
+			}
+
+			return 1, nil
+		}
+	)
+
+	results, errs := RunAll(
+		testCtx,
+		suite.newRunner(),
+		[]Task[int]{task, task, task, task},
+		WithMaxConcurrency(1),
+		WithStopOnFailure(),
+	)
+
+	suite.Equal([]int{1, -1, 0, 0}, results)
+	suite.Require().Len(errs, 4)
+	suite.NoError(errs[0])
+	suite.ErrorIs(errs[1], expectedErr)
+	suite.ErrorIs(errs[2], ErrNotRun)
+	suite.ErrorIs(errs[3], ErrNotRun)
+	suite.Equal(int32(2), calls.Load())
+}
+
+func (suite *RunAllSuite) TestContinueOnFailure() {
+	var (
+		testCtx, _  = suite.testCtx()
+		expectedErr = errors.New("expected")
+		task        = func(context.Context) (int, error) {
+			return -1, expectedErr
+		}
+	)
+
+	results, errs := RunAll(
+		testCtx,
+		suite.newRunner(),
+		[]Task[int]{task, task, task},
+		WithMaxConcurrency(1),
+	)
+
+	suite.Equal([]int{-1, -1, -1}, results)
+	suite.Require().Len(errs, 3)
+	for _, err := range errs {
+		suite.ErrorIs(err, expectedErr)
+	}
+}
+
+func (suite *RunAllSuite) TestCanceled() {
+	var (
+		testCtx, testCancel = suite.testCtx()
+		task                = new(mockTask[int])
+	)
+
+	testCancel()
+	results, errs := RunAll(
+		testCtx,
+		suite.newRunner(),
+		[]Task[int]{task.Do, task.Do},
+	)
+
+	suite.Equal([]int{0, 0}, results)
+	suite.Require().Len(errs, 2)
+	suite.ErrorIs(errs[0], context.Canceled)
+	suite.ErrorIs(errs[1], context.Canceled)
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *RunAllSuite) TestRunEachSerialCallback() {
+	var (
+		testCtx, _ = suite.testCtx()
+		maxRunning atomic.Int32
+		tasks      = suite.newTasks(4, &maxRunning)
+		seen       = make(map[int]int)
+	)
+
+	// the callback is unsynchronized, so the race detector verifies that
+	// it is only invoked from this goroutine
+	RunEach(testCtx, suite.newRunner(), tasks, func(i int, result int, err error) {
+		suite.NoError(err)
+		seen[i] = result
+	})
+
+	suite.Equal(map[int]int{0: 0, 1: 10, 2: 20, 3: 30}, seen)
+}
+
+func (suite *RunAllSuite) TestNoTasks() {
+	testCtx, _ := suite.testCtx()
+	results, errs := RunAll(testCtx, suite.newRunner(), nil)
+	suite.Empty(results)
+	suite.Empty(errs)
+}
+
+func TestRunAll(t *testing.T) {
+	suite.Run(t, new(RunAllSuite))
+}
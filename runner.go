@@ -5,6 +5,8 @@ package retry
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -19,7 +21,8 @@ type runnerOptionFunc[V any] func(*runner[V]) error
 func (rof runnerOptionFunc[V]) apply(r *runner[V]) error { return rof(r) }
 
 // WithTimer supplies a custom Timer for the Runner.  This option is primarily
-// useful for testing.
+// useful for testing.  A Timer set with this option takes precedence over the
+// Clock when waiting between retries.
 func WithTimer[V any](t Timer) RunnerOption[V] {
 	return runnerOptionFunc[V](func(r *runner[V]) error {
 		r.timer = t
@@ -48,9 +51,32 @@ func WithPolicyFactory[V any](pf PolicyFactory) RunnerOption[V] {
 // used to determine if an error should be retried or should halt further attempts.
 // This predicate is used if the error itself does not expose retryablity semantics
 // via a ShouldRetry method.
+//
+// This option, WithShouldRetryAttempt, and WithClassifier replace each other.  The last
+// one applied is used.
 func WithShouldRetry[V any](sr ShouldRetry[V]) RunnerOption[V] {
 	return runnerOptionFunc[V](func(r *runner[V]) error {
-		r.shouldRetry = sr
+		r.shouldRetry = nil
+		if sr != nil {
+			r.shouldRetry = func(a Attempt[V]) bool {
+				return sr(a.Result, a.Err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// WithShouldRetryAttempt is like WithShouldRetry, but the predicate receives the entire
+// Attempt.  This allows retry decisions that take into account the number of retries so far,
+// the elapsed time, or the policy context.  The Attempt's Next field is always zero when passed
+// to the predicate, since the policy has not yet been consulted.
+//
+// This option, WithShouldRetry, and WithClassifier replace each other.  The last one
+// applied is used.
+func WithShouldRetryAttempt[V any](sra ShouldRetryAttempt[V]) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.shouldRetry = sra
 		return nil
 	})
 }
@@ -66,6 +92,9 @@ func WithOnAttempt[V any](fns ...OnAttempt[V]) RunnerOption[V] {
 
 // Runner is a task executor that honors retry semantics.  A Runner is associated
 // with a PolicyFactory, a ShouldRetry strategy, and one or more OnAttempt callbacks.
+//
+// Implementations outside this package can use the package-level RunAsync function
+// to implement the RunAsync method.
 type Runner[V any] interface {
 	// Run executes a task at least once, retrying failures according to
 	// the configured PolicyFactory.  If all attempts fail, this method returns
@@ -75,17 +104,42 @@ type Runner[V any] interface {
 	// The context passed to this method must never be nil.  Use context.Background()
 	// or context.TODO() as appropriate rather than nil.
 	//
+	// If the context is already canceled or past its deadline, the task is not executed
+	// at all, and the context's error is returned.
+	//
 	// The configured PolicyFactory may impose a time limit, e.g. the Config.MaxElapsedTime
 	// field.  In this case, if the time limit is reached, task attempts will halt regardless
 	// of the state of the parent context.
 	Run(context.Context, Task[V]) (V, error)
+
+	// RunAsync executes a task in the background with the same semantics as Run.
+	// The returned Future can be used to wait for or cancel the task, as well as
+	// to observe the progress of its attempts.
+	//
+	// The context passed to this method must never be nil.  Canceling it has the
+	// same effect as calling Future.Cancel.
+	RunAsync(context.Context, Task[V]) *Future[V]
 }
 
 type runner[V any] struct {
 	factory     PolicyFactory
-	shouldRetry ShouldRetry[V]
+	shouldRetry ShouldRetryAttempt[V]
+	classifier  *Classifier
 	onAttempts  []OnAttempt[V]
 	timer       func(time.Duration) (<-chan time.Time, func() bool)
+	clock       Clock
+
+	hedgeDelay       time.Duration
+	hedgeMaxInFlight int
+	hedgeCleanups    []HedgeCleanup[V]
+
+	budget  *Budget
+	breaker *CircuitBreaker
+	metrics *runnerMetrics
+	tracer  Tracer
+	hooks   []Hooks[V]
+
+	interceptors []Interceptor[V]
 }
 
 // newPolicy creates a Policy for a series of attempts.
@@ -101,42 +155,178 @@ func (r *runner[V]) newPolicy(ctx context.Context) Policy {
 	return r.factory.NewPolicy(ctx)
 }
 
+// testRetry applies the configured ShouldRetry strategy to the results of a task attempt.
+// The policy is not consulted.
+func (r *runner[V]) testRetry(a Attempt[V]) bool {
+	if r.shouldRetry != nil {
+		return r.shouldRetry(a)
+	}
+
+	return DefaultTestErrorForRetry(a.Err)
+}
+
+// runState holds the state of a single call to Run.
+type runState[V any] struct {
+	// parentCtx is the context passed to Run, which may carry a Clock
+	parentCtx context.Context
+
+	policy   Policy
+	retries  int
+	attempts int
+	reason   StopReason
+	last     Attempt[V]
+	clock    Clock
+	timer    Timer
+	start    time.Time
+
+	// progress is an optional callback invoked after any configured OnAttempt callbacks
+	progress OnAttempt[V]
+
+	// breakerGeneration is the CircuitBreaker generation that allowed the current attempt
+	breakerGeneration uint64
+
+	// the spans for the run and the current attempt, if there is a Tracer
+	runSpan     Span
+	attemptSpan Span
+}
+
+// newAttempt creates an Attempt for the results of the current task attempt.
+// The Next field is left unset.
+func (r *runner[V]) newAttempt(rs *runState[V], result V, err error) Attempt[V] {
+	return Attempt[V]{
+		Context:  rs.policy.Context(),
+		Result:   result,
+		Err:      err,
+		Category: r.classifier.Categorize(err),
+		Retries:  rs.retries,
+		Elapsed:  rs.clock.Now().Sub(rs.start),
+	}
+}
+
+// allowAttempt consults the CircuitBreaker, if any, to see if an attempt may proceed.
+func (r *runner[V]) allowAttempt(rs *runState[V]) (err error) {
+	if r.breaker != nil {
+		rs.breakerGeneration, err = r.breaker.allow()
+	}
+
+	return
+}
+
+// recordBreaker reports the outcome of an attempt to the CircuitBreaker.  An attempt that
+// failed because the caller canceled the run says nothing about the health of the
+// dependency, so it is released rather than recorded.  Timeouts of individual attempts
+// are still recorded, since they are how a hung dependency manifests.
+func (r *runner[V]) recordBreaker(rs *runState[V], a Attempt[V], failed bool) {
+	if parentErr := rs.parentCtx.Err(); parentErr != nil && errors.Is(a.Err, parentErr) {
+		r.breaker.release(rs.breakerGeneration)
+		return
+	}
+
+	r.breaker.record(rs.breakerGeneration, failed)
+}
+
+// scheduleRetry consults the policy, any interceptors, and any budget to decide whether a
+// retry will happen.  If so, the Attempt's Next field is set to the interval.  If an
+// interceptor aborts the run, the Attempt's Err field may be replaced.
+func (r *runner[V]) scheduleRetry(rs *runState[V], a *Attempt[V]) bool {
+	interval, ok := rs.policy.Next()
+	if !ok {
+		rs.reason = rs.contextStopReason()
+		return false
+	}
+
+	if interval, ok = r.intercept(rs, a, interval); !ok {
+		return false
+	}
+
+	if r.budget != nil && !r.budget.withdraw() {
+		rs.reason = StopBudget
+		return false
+	}
+
+	a.Next = interval
+	return true
+}
+
 // handleAttempt deals with the aftermath of a task attempt, whether success or fail.
 // If onAttempt is set, it is invoked with an Attempt.  If the policy and the error
 // allow retries to continue, then interval will be positive and shouldRetry will be true.
-func (r *runner[V]) handleAttempt(p Policy, retries int, result V, err error) (interval time.Duration, shouldRetry bool) {
-	a := Attempt[V]{
-		Context: p.Context(),
-		Result:  result,
-		Err:     err,
-		Retries: retries,
+// The returned error is the one to report if there are no more retries, which an
+// interceptor may have substituted.
+func (r *runner[V]) handleAttempt(rs *runState[V], result V, err error) (interval time.Duration, shouldRetry bool, finalErr error) {
+	rs.attempts++
+	r.metrics.attempted()
+	a := r.newAttempt(rs, result, err)
+	shouldRetry = r.testRetry(a)
+	switch {
+	case shouldRetry:
+		// the policy decides below
+	case err == nil:
+		rs.reason = StopSuccess
+	case rs.policyErr(rs.parentCtx) != nil:
+		// the error is most likely the result of the caller giving up
+		rs.reason = StopCanceled
+	default:
+		rs.reason = StopPermanent
 	}
 
-	if r.shouldRetry != nil {
-		shouldRetry = r.shouldRetry(result, err)
-	} else {
-		shouldRetry = DefaultTestErrorForRetry(err)
+	if r.budget != nil && err == nil && !shouldRetry {
+		r.budget.deposit()
+	}
+
+	if r.breaker != nil {
+		r.recordBreaker(rs, a, shouldRetry)
 	}
 
 	// slight optimization: if the error indicated no further retries, then there's no
 	// reason to consult the policy
 	if shouldRetry {
-		interval, shouldRetry = p.Next()
-		a.Next = interval
+		shouldRetry = r.scheduleRetry(rs, &a)
+		interval = a.Next
 	}
 
+	rs.endAttemptSpan(a)
+	rs.last = a
+
 	for _, f := range r.onAttempts {
 		f(a)
 	}
 
+	if rs.progress != nil {
+		rs.progress(a)
+	}
+
+	if shouldRetry {
+		r.onRetryScheduled(a)
+	}
+
+	finalErr = a.Err
 	return
 }
 
+// policyErr returns a non-nil error if no further attempts may start.  A deadline that has
+// passed is honored even if the context has not been canceled yet, since the deadline and
+// a retry timer that fire at the same instant race each other.
+func (rs *runState[V]) policyErr(taskCtx context.Context) error {
+	return clockContextErr(taskCtx, rs.clock)
+}
+
+// contextStopReason determines why a run stopped when the policy ended.  The
+// policy's own limits, such as Config.MaxElapsedTime, are distinguished from the caller
+// canceling the parent context.
+func (rs *runState[V]) contextStopReason() StopReason {
+	if rs.policyErr(rs.parentCtx) != nil {
+		return StopCanceled
+	}
+
+	return StopExhausted
+}
+
 // awaitRetry waits out the interval before returning.  If taskCtx is canceled while waiting,
 // this method returns taskCtx.Err().  Otherwise, this method return nil and the next retry
 // may continue.
-func (r *runner[V]) awaitRetry(taskCtx context.Context, interval time.Duration) (err error) {
-	ch, stop := r.timer(interval)
+func (r *runner[V]) awaitRetry(rs *runState[V], taskCtx context.Context, interval time.Duration) (err error) {
+	ch, stop := rs.timer(interval)
 	select {
 	case <-taskCtx.Done():
 		err = taskCtx.Err()
@@ -149,23 +339,121 @@ func (r *runner[V]) awaitRetry(taskCtx context.Context, interval time.Duration)
 	return
 }
 
-func (r *runner[V]) Run(parentCtx context.Context, task Task[V]) (result V, err error) {
-	p := r.newPolicy(parentCtx)
-	defer p.Cancel()
+func (r *runner[V]) Run(parentCtx context.Context, task Task[V]) (V, error) {
+	return r.run(parentCtx, task, nil)
+}
+
+func (r *runner[V]) RunAsync(parentCtx context.Context, task Task[V]) *Future[V] {
+	ctx, cancel := context.WithCancel(parentCtx)
+	f := newFuture[V](cancel)
+	go func() {
+		defer cancel()
+		f.complete(r.run(ctx, task, f.onAttempt))
+	}()
+
+	return f
+}
+
+// newRunState creates the state for a single call to Run.  If this runner has a Clock,
+// the state's parent context carries it so that the PolicyFactory and the task can use it.
+func (r *runner[V]) newRunState(parentCtx context.Context, progress OnAttempt[V]) *runState[V] {
+	if r.clock != nil {
+		parentCtx = ContextWithClock(parentCtx, r.clock)
+	}
+
+	rs := &runState[V]{
+		parentCtx: parentCtx,
+		clock:     ClockFromContext(parentCtx),
+		timer:     r.timer,
+		progress:  progress,
+	}
+
+	if rs.timer == nil {
+		rs.timer = rs.clock.NewTimer
+	}
+
+	rs.start = rs.clock.Now()
+	return rs
+}
+
+// run is the common implementation for executing a task.  The optional progress
+// callback receives each attempt in addition to any configured OnAttempt callbacks.
+func (r *runner[V]) run(parentCtx context.Context, task Task[V], progress OnAttempt[V]) (result V, err error) {
+	rs := r.newRunState(parentCtx, progress)
+	r.startRunSpan(rs)
+	r.metrics.runStarted()
+	r.onStart(rs)
+	defer func() {
+		r.metrics.runEnded(rs.reason, rs.attempts, rs.clock.Now().Sub(rs.start))
+
+		// the end hooks run before the policy is canceled, so that the last
+		// Attempt's context is still valid inside them
+		r.onEnd(rs, err)
+		if rs.policy != nil {
+			rs.policy.Cancel()
+		}
+
+		rs.endRunSpan(err)
+	}()
+
+	// an open circuit breaker halts things before any policy is created
+	if err = r.allowAttempt(rs); err != nil {
+		rs.reason = StopCircuitOpen
+		return
+	}
+
+	rs.policy = r.newPolicy(rs.parentCtx)
 
-	var attemptResult V
-	for taskCtx, retries := p.Context(), 0; taskCtx.Err() == nil; retries++ {
-		attemptResult, err = task(taskCtx)
-		interval, keepTrying := r.handleAttempt(p, retries, attemptResult, err)
+	if r.hedgeMaxInFlight > 1 {
+		task = r.hedge(rs, task)
+	}
+
+	return r.runAttempts(rs, task)
+}
+
+// runAttempts executes the task until it succeeds or there is a reason to stop.
+func (r *runner[V]) runAttempts(rs *runState[V], task Task[V]) (result V, err error) {
+	var (
+		attemptResult V
+		sleep         time.Duration
+	)
+
+	for taskCtx := rs.policy.Context(); ; rs.retries++ {
+		// don't start an attempt once the policy context is done, even the first one
+		if err = rs.policyErr(taskCtx); err != nil {
+			if rs.retries == 0 && r.breaker != nil {
+				r.breaker.release(rs.breakerGeneration)
+			}
+
+			rs.reason = rs.contextStopReason()
+			break
+		}
+
+		if rs.retries > 0 {
+			if err = r.allowAttempt(rs); err != nil {
+				// keep the error from the last attempt, so callers can see why the run failed
+				err = fmt.Errorf("%w: %w", err, rs.last.Err)
+				rs.reason = StopCircuitOpen
+				break
+			}
+		}
+
+		attemptCtx := r.beforeAttempt(rs, r.startAttemptSpan(rs, taskCtx, sleep))
+		attemptResult, err = task(attemptCtx)
+		interval, keepTrying, finalErr := r.handleAttempt(rs, attemptResult, err)
 		if !keepTrying {
-			result = attemptResult
+			result, err = attemptResult, finalErr
 			break
 		}
 
-		err = r.awaitRetry(taskCtx, interval)
+		err = r.awaitRetry(rs, taskCtx, interval)
 		if err != nil {
+			rs.reason = rs.contextStopReason()
 			break
 		}
+
+		r.metrics.retrying(interval)
+		sleep = interval
 	}
 
 	return
@@ -180,8 +468,8 @@ func NewRunner[V any](opts ...RunnerOption[V]) (Runner[V], error) {
 		}
 	}
 
-	if r.timer == nil {
-		r.timer = defaultTimer
+	if r.classifier == nil {
+		r.classifier = DefaultClassifier
 	}
 
 	return r, nil
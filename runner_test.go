@@ -4,6 +4,7 @@
 package retry
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -169,10 +170,95 @@ func (suite *RunnerSuite) testRunWithRetriesAndCanceled() {
 	task.AssertExpectations(suite.T())
 }
 
+func (suite *RunnerSuite) testRunAlreadyCanceled() {
+	var (
+		testCtx, testCancel = suite.testCtx()
+		task                = new(mockTask[int])
+		runner              = suite.newRunner(
+			WithOnAttempt(func(Attempt[int]) {
+				suite.Fail("no attempt should have been made")
+			}),
+			WithPolicyFactory[int](Config{
+				Interval: time.Second,
+			}),
+		)
+	)
+
+	testCancel()
+	result, err := runner.Run(testCtx, task.Do)
+	suite.Equal(0, result)
+	suite.ErrorIs(err, context.Canceled, "an unexecuted task must not be reported as a success")
+
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *RunnerSuite) testRunDeadlinePassed() {
+	var (
+		testCtx, _ = suite.testCtx()
+		task       = new(mockTask[int])
+		runner     = suite.newRunner(
+			WithOnAttempt(func(Attempt[int]) {
+				suite.Fail("no attempt should have been made")
+			}),
+		)
+	)
+
+	deadlineCtx, cancel := context.WithDeadline(testCtx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	result, err := runner.Run(deadlineCtx, task.Do)
+	suite.Equal(0, result)
+	suite.ErrorIs(err, context.DeadlineExceeded, "an unexecuted task must not be reported as a success")
+
+	task.AssertExpectations(suite.T())
+}
+
+func (suite *RunnerSuite) testRunWithShouldRetryAttempt() {
+	var (
+		testCtx, _  = suite.testCtx()
+		task        = new(mockTask[int])
+		conflictErr = errors.New("conflict")
+		otherErr    = errors.New("other")
+		seen        []int
+		runner      = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithShouldRetry(func(int, error) bool {
+				suite.Fail("WithShouldRetryAttempt should have replaced this predicate")
+				return false
+			}),
+			WithShouldRetryAttempt(func(a Attempt[int]) bool {
+				suite.assertTestCtx(a.Context)
+				suite.GreaterOrEqual(a.Elapsed, time.Duration(0))
+				seen = append(seen, a.Retries)
+				if errors.Is(a.Err, conflictErr) {
+					return a.Retries < 2
+				}
+
+				return a.Err != nil
+			}),
+			WithPolicyFactory[int](Config{
+				Interval:   5 * time.Second,
+				MaxRetries: 10,
+			}),
+		)
+	)
+
+	task.ExpectMatch(suite.assertTestCtx, -1, otherErr).Once()
+	task.ExpectMatch(suite.assertTestCtx, -1, conflictErr).Times(2)
+
+	_, err := runner.Run(testCtx, task.Do)
+	suite.ErrorIs(err, conflictErr)
+	suite.Equal([]int{0, 1, 2}, seen)
+	task.AssertExpectations(suite.T())
+}
+
 func (suite *RunnerSuite) TestRun() {
 	suite.Run("NoRetries", suite.testRunNoRetries)
 	suite.Run("WithRetriesUntilSuccess", suite.testRunWithRetriesUntilSuccess)
 	suite.Run("WithRetriesAndCanceled", suite.testRunWithRetriesAndCanceled)
+	suite.Run("AlreadyCanceled", suite.testRunAlreadyCanceled)
+	suite.Run("DeadlinePassed", suite.testRunDeadlinePassed)
+	suite.Run("WithShouldRetryAttempt", suite.testRunWithShouldRetryAttempt)
 }
 
 func (suite *RunnerSuite) TestOptionError() {
@@ -46,6 +46,8 @@ type ShouldRetry[V any] func(V, error) bool
 // - if err implements ShouldRetryable, then err.ShouldRetry() is returned
 // - if err supplies a Temporary() bool method, then err.Temporary() is returned
 // - failing other logic, this function returns true
+//
+// See ClassifyForRetry for a strategy that understands common standard library errors.
 func DefaultTestErrorForRetry(err error) bool {
 	if err == nil {
 		return false // successful task result, so no retries are necessary
@@ -67,3 +69,102 @@ func DefaultTestErrorForRetry(err error) bool {
 
 	return true
 }
+
+// DefaultShouldRetry returns a ShouldRetry predicate that applies DefaultTestErrorForRetry
+// and ignores the value result.  This is useful as a building block with Any and All.
+func DefaultShouldRetry[V any]() ShouldRetry[V] {
+	return func(_ V, err error) bool {
+		return DefaultTestErrorForRetry(err)
+	}
+}
+
+// Any returns a ShouldRetry predicate that retries if any of the given predicates
+// would retry.  The predicates are evaluated in order, and evaluation stops at
+// the first one that returns true.  With no predicates, the returned predicate
+// never retries.
+func Any[V any](srs ...ShouldRetry[V]) ShouldRetry[V] {
+	srs = append([]ShouldRetry[V](nil), srs...)
+	return func(result V, err error) bool {
+		for _, sr := range srs {
+			if sr(result, err) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// All returns a ShouldRetry predicate that retries only if all the given predicates
+// would retry.  The predicates are evaluated in order, and evaluation stops at the
+// first one that returns false.  With no predicates, the returned predicate never retries.
+func All[V any](srs ...ShouldRetry[V]) ShouldRetry[V] {
+	srs = append([]ShouldRetry[V](nil), srs...)
+	return func(result V, err error) bool {
+		for _, sr := range srs {
+			if !sr(result, err) {
+				return false
+			}
+		}
+
+		return len(srs) > 0
+	}
+}
+
+// Not returns a ShouldRetry predicate that negates the given predicate.  Note that
+// a negated predicate will retry successful results unless combined with another
+// predicate, e.g. All(Not(sr), DefaultShouldRetry[V]()).
+func Not[V any](sr ShouldRetry[V]) ShouldRetry[V] {
+	return func(result V, err error) bool {
+		return !sr(result, err)
+	}
+}
+
+// RetryOnErrors returns a ShouldRetry predicate that retries only errors that match
+// at least one of the targets according to errors.Is.  Successful results are never retried.
+func RetryOnErrors[V any](targets ...error) ShouldRetry[V] {
+	targets = append([]error(nil), targets...)
+	return func(_ V, err error) bool {
+		return err != nil && isAny(err, targets)
+	}
+}
+
+// StopOnErrors returns a ShouldRetry predicate that retries every error except those
+// that match at least one of the targets according to errors.Is.  Successful results
+// are never retried.
+func StopOnErrors[V any](targets ...error) ShouldRetry[V] {
+	targets = append([]error(nil), targets...)
+	return func(_ V, err error) bool {
+		return err != nil && !isAny(err, targets)
+	}
+}
+
+// RetryOnType returns a ShouldRetry predicate that retries only errors that have an
+// error of type T in their chain according to errors.As.  Successful results are never retried.
+func RetryOnType[V any, T error]() ShouldRetry[V] {
+	return func(_ V, err error) bool {
+		var target T
+		return err != nil && errors.As(err, &target)
+	}
+}
+
+// RetryOnResult returns a ShouldRetry predicate that retries a successful result, i.e. one
+// with a nil error, if the given function returns true for it.  Errors are never retried by
+// the returned predicate, so it is typically combined with another predicate via Any, e.g.
+// Any(RetryOnResult(f), DefaultShouldRetry[V]()).
+func RetryOnResult[V any](f func(V) bool) ShouldRetry[V] {
+	return func(result V, err error) bool {
+		return err == nil && f(result)
+	}
+}
+
+// isAny tests if err matches any of the targets via errors.Is.
+func isAny(err error, targets []error) bool {
+	for _, t := range targets {
+		if errors.Is(err, t) {
+			return true
+		}
+	}
+
+	return false
+}
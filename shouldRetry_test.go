@@ -6,6 +6,7 @@ package retry
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"testing"
 
@@ -80,6 +81,93 @@ func (suite *ShouldRetrySuite) TestDefaultTestErrorForRetry() {
 	})
 }
 
+func (suite *ShouldRetrySuite) TestDefaultShouldRetry() {
+	sr := DefaultShouldRetry[int]()
+	suite.Require().NotNil(sr)
+	suite.False(sr(1, nil))
+	suite.True(sr(1, errors.New("expected")))
+	suite.False(sr(1, SetRetryable(errors.New("expected"), false)))
+}
+
+func (suite *ShouldRetrySuite) TestAny() {
+	var (
+		yes = func(int, error) bool { return true }
+		no  = func(int, error) bool { return false }
+	)
+
+	suite.False(Any[int]()(0, nil))
+	suite.False(Any[int](no, no)(0, nil))
+	suite.True(Any[int](no, yes)(0, nil))
+	suite.True(Any[int](yes, func(int, error) bool {
+		suite.Fail("evaluation should have stopped at the first true predicate")
+		return false
+	})(0, nil))
+}
+
+func (suite *ShouldRetrySuite) TestAll() {
+	var (
+		yes = func(int, error) bool { return true }
+		no  = func(int, error) bool { return false }
+	)
+
+	suite.False(All[int]()(0, nil))
+	suite.True(All[int](yes, yes)(0, nil))
+	suite.False(All[int](yes, no)(0, nil))
+	suite.False(All[int](no, func(int, error) bool {
+		suite.Fail("evaluation should have stopped at the first false predicate")
+		return true
+	})(0, nil))
+}
+
+func (suite *ShouldRetrySuite) TestNot() {
+	sr := Not(RetryOnErrors[int](io.EOF))
+	suite.False(sr(0, io.EOF))
+	suite.True(sr(0, io.ErrUnexpectedEOF))
+	suite.True(sr(0, nil))
+
+	suite.False(
+		All(Not(RetryOnErrors[int](io.EOF)), DefaultShouldRetry[int]())(0, nil),
+	)
+}
+
+func (suite *ShouldRetrySuite) TestRetryOnErrors() {
+	sr := RetryOnErrors[int](io.EOF, io.ErrUnexpectedEOF)
+	suite.False(sr(0, nil))
+	suite.True(sr(0, io.EOF))
+	suite.True(sr(0, fmt.Errorf("wrapped: %w", io.ErrUnexpectedEOF)))
+	suite.False(sr(0, errors.New("other")))
+	suite.False(RetryOnErrors[int]()(0, io.EOF))
+}
+
+func (suite *ShouldRetrySuite) TestStopOnErrors() {
+	sr := StopOnErrors[int](io.EOF)
+	suite.False(sr(0, nil))
+	suite.False(sr(0, io.EOF))
+	suite.False(sr(0, fmt.Errorf("wrapped: %w", io.EOF)))
+	suite.True(sr(0, errors.New("other")))
+	suite.True(StopOnErrors[int]()(0, io.EOF))
+}
+
+func (suite *ShouldRetrySuite) TestRetryOnType() {
+	sr := RetryOnType[int, *net.DNSError]()
+	suite.False(sr(0, nil))
+	suite.True(sr(0, &net.DNSError{}))
+	suite.True(sr(0, fmt.Errorf("wrapped: %w", &net.DNSError{})))
+	suite.False(sr(0, errors.New("other")))
+}
+
+func (suite *ShouldRetrySuite) TestRetryOnResult() {
+	sr := RetryOnResult(func(v int) bool { return v < 0 })
+	suite.True(sr(-1, nil))
+	suite.False(sr(1, nil))
+	suite.False(sr(-1, errors.New("expected")))
+
+	combined := Any(sr, DefaultShouldRetry[int]())
+	suite.True(combined(-1, nil))
+	suite.True(combined(1, errors.New("expected")))
+	suite.False(combined(1, nil))
+}
+
 func TestShouldRetry(t *testing.T) {
 	suite.Run(t, new(ShouldRetrySuite))
 }
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+// StopReason describes why a Runner stopped attempting a task.
+type StopReason int
+
+const (
+	// StopSuccess indicates that the last attempt succeeded, i.e. it returned no error
+	// and the ShouldRetry strategy did not ask for a retry.
+	StopSuccess StopReason = iota
+
+	// StopPermanent indicates that the last attempt returned an error that the ShouldRetry
+	// strategy did not consider retryable, while the context passed to the runner was
+	// still valid.
+	StopPermanent
+
+	// StopExhausted indicates that the policy refused any more retries, e.g. because
+	// Config.MaxRetries or Config.MaxElapsedTime was reached.
+	StopExhausted
+
+	// StopBudget indicates that a retry was suppressed because the runner's Budget was empty.
+	StopBudget
+
+	// StopCircuitOpen indicates that the runner's CircuitBreaker refused an attempt.
+	StopCircuitOpen
+
+	// StopIntercepted indicates that an Interceptor aborted the run.
+	StopIntercepted
+
+	// StopCanceled indicates that the context passed to the runner was canceled or
+	// reached its deadline.  This takes precedence over StopPermanent when an attempt
+	// fails after the context has ended.
+	StopCanceled
+)
+
+// String returns a human-readable label for this reason, suitable for logs and metrics.
+func (sr StopReason) String() string {
+	switch sr {
+	case StopSuccess:
+		return "success"
+
+	case StopPermanent:
+		return "permanent"
+
+	case StopExhausted:
+		return "exhausted"
+
+	case StopBudget:
+		return "budget"
+
+	case StopCircuitOpen:
+		return "circuitOpen"
+
+	case StopIntercepted:
+		return "intercepted"
+
+	case StopCanceled:
+		return "canceled"
+
+	default:
+		return "unknown"
+	}
+}
+
+// stopReasons is the set of all valid StopReasons.
+var stopReasons = []StopReason{
+	StopSuccess, StopPermanent, StopExhausted, StopBudget, StopCircuitOpen, StopIntercepted, StopCanceled,
+}
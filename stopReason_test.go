@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StopReasonSuite struct {
+	suite.Suite
+}
+
+func (suite *StopReasonSuite) TestString() {
+	suite.Equal("success", StopSuccess.String())
+	suite.Equal("permanent", StopPermanent.String())
+	suite.Equal("exhausted", StopExhausted.String())
+	suite.Equal("budget", StopBudget.String())
+	suite.Equal("circuitOpen", StopCircuitOpen.String())
+	suite.Equal("intercepted", StopIntercepted.String())
+	suite.Equal("canceled", StopCanceled.String())
+	suite.Equal("unknown", StopReason(-1).String())
+}
+
+func TestStopReason(t *testing.T) {
+	suite.Run(t, new(StopReasonSuite))
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build go1.25
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// SynctestSuite verifies that runners and Config policies behave correctly with the
+// fake time inside a testing/synctest bubble, without swapping in a custom Timer or Clock.
+type SynctestSuite struct {
+	CommonSuite
+
+	retryErr error
+	attempts []Attempt[int]
+}
+
+func (suite *SynctestSuite) SetupTest() {
+	suite.retryErr = errors.New("should retry this")
+	suite.attempts = nil
+}
+
+// bubble runs f inside a synctest bubble.  While f runs, suite.T() refers to the bubble's test.
+func (suite *SynctestSuite) bubble(f func()) {
+	outer := suite.T()
+	synctest.Test(outer, func(t *testing.T) {
+		suite.SetT(t)
+		defer suite.SetT(outer)
+		f()
+	})
+}
+
+func (suite *SynctestSuite) onAttempt(a Attempt[int]) {
+	suite.attempts = append(suite.attempts, a)
+}
+
+// newConfigRunner creates a runner that records attempts and uses the default Clock.
+func (suite *SynctestSuite) newConfigRunner(cfg Config) Runner[int] {
+	return suite.newRunner(
+		WithPolicyFactory[int](cfg),
+		WithOnAttempt(suite.onAttempt),
+	)
+}
+
+func (suite *SynctestSuite) failingTask(context.Context) (int, error) {
+	return -1, suite.retryErr
+}
+
+// assertSchedule verifies the Next and Elapsed values of each recorded attempt.
+func (suite *SynctestSuite) assertSchedule(next, elapsed []time.Duration) {
+	suite.Require().Len(suite.attempts, len(next))
+	for i, a := range suite.attempts {
+		suite.Equal(next[i], a.Next, "attempt %d", i)
+		suite.Equal(elapsed[i], a.Elapsed, "attempt %d", i)
+	}
+}
+
+func (suite *SynctestSuite) TestConstant() {
+	suite.bubble(func() {
+		runner := suite.newConfigRunner(Config{
+			Interval:   5 * time.Second,
+			MaxRetries: 3,
+		})
+
+		start := time.Now()
+		_, err := runner.Run(context.Background(), suite.failingTask)
+		suite.ErrorIs(err, suite.retryErr)
+		suite.Equal(15*time.Second, time.Since(start))
+		suite.assertSchedule(
+			[]time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second, 0},
+			[]time.Duration{0, 5 * time.Second, 10 * time.Second, 15 * time.Second},
+		)
+	})
+}
+
+func (suite *SynctestSuite) TestExponential() {
+	suite.bubble(func() {
+		runner := suite.newConfigRunner(Config{
+			Interval:    time.Second,
+			Multiplier:  2.0,
+			MaxInterval: 8 * time.Second,
+			MaxRetries:  5,
+		})
+
+		start := time.Now()
+		_, err := runner.Run(context.Background(), suite.failingTask)
+		suite.ErrorIs(err, suite.retryErr)
+		suite.Equal(23*time.Second, time.Since(start))
+		suite.assertSchedule(
+			[]time.Duration{
+				time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second, 0,
+			},
+			[]time.Duration{
+				0, time.Second, 3 * time.Second, 7 * time.Second, 15 * time.Second, 23 * time.Second,
+			},
+		)
+	})
+}
+
+func (suite *SynctestSuite) TestJitter() {
+	suite.bubble(func() {
+		runner := suite.newConfigRunner(Config{
+			Interval:   10 * time.Second,
+			Jitter:     0.5,
+			MaxRetries: 3,
+		})
+
+		_, err := runner.Run(context.Background(), suite.failingTask)
+		suite.ErrorIs(err, suite.retryErr)
+		suite.Require().Len(suite.attempts, 4)
+
+		var total time.Duration
+		for _, a := range suite.attempts {
+			suite.Equal(total, a.Elapsed, "each retry must wait exactly the jittered interval")
+			if !a.Done() {
+				suite.GreaterOrEqual(a.Next, 5*time.Second)
+				suite.LessOrEqual(a.Next, 15*time.Second)
+			}
+
+			total += a.Next
+		}
+	})
+}
+
+func (suite *SynctestSuite) TestMaxElapsedTime() {
+	suite.bubble(func() {
+		runner := suite.newConfigRunner(Config{
+			Interval:       time.Minute,
+			MaxElapsedTime: 5 * time.Minute,
+		})
+
+		start := time.Now()
+		_, err := runner.Run(context.Background(), suite.failingTask)
+		suite.ErrorIs(err, context.DeadlineExceeded)
+		suite.Equal(5*time.Minute, time.Since(start))
+		suite.Len(suite.attempts, 5)
+	})
+}
+
+func (suite *SynctestSuite) TestRunAsyncCancel() {
+	suite.bubble(func() {
+		runner := suite.newConfigRunner(Config{
+			Interval: time.Minute,
+		})
+
+		f := runner.RunAsync(context.Background(), suite.failingTask)
+		time.Sleep(150 * time.Second)
+		suite.Equal(3, f.Attempts())
+
+		f.Cancel()
+		_, err := f.Wait(context.Background())
+		suite.ErrorIs(err, context.Canceled)
+	})
+}
+
+func (suite *SynctestSuite) TestCircuitBreaker() {
+	suite.bubble(func() {
+		var (
+			cb = NewCircuitBreaker(BreakerConfig{
+				ConsecutiveFailures: 2,
+				OpenTimeout:         time.Minute,
+			})
+
+			runner = suite.newRunner(
+				WithCircuitBreaker[int](cb),
+				WithPolicyFactory[int](Config{
+					Interval: 10 * time.Second,
+				}),
+			)
+		)
+
+		_, err := runner.Run(context.Background(), suite.failingTask)
+		suite.ErrorIs(err, ErrCircuitOpen)
+		suite.Equal(BreakerOpen, cb.State())
+
+		time.Sleep(time.Minute)
+		suite.Equal(BreakerHalfOpen, cb.State())
+	})
+}
+
+func (suite *SynctestSuite) TestAttempts() {
+	suite.bubble(func() {
+		var elapsed []time.Duration
+		start := time.Now()
+		for a := range Attempts(context.Background(), Config{
+			Interval:       time.Minute,
+			MaxElapsedTime: 3*time.Minute + 30*time.Second,
+		}) {
+			elapsed = append(elapsed, a.Elapsed)
+		}
+
+		suite.Equal(
+			[]time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute},
+			elapsed,
+		)
+
+		suite.Equal(3*time.Minute+30*time.Second, time.Since(start))
+	})
+}
+
+func TestSynctest(t *testing.T) {
+	suite.Run(t, new(SynctestSuite))
+}
@@ -3,7 +3,10 @@
 
 package retry
 
-import "context"
+import (
+	"context"
+	"reflect"
+)
 
 // Task is the basic type of closure that can be retried in the face of errors.
 // This package provides a few convenient ways of coercing functions with
@@ -18,18 +21,43 @@ type RunnableTask[V any] interface {
 }
 
 // AsTask normalizes any RunnableTask into a Task so that it can be submitted
-// to a Runner.
+// to a Runner.  Named function types, such as Task itself, are supported.
 func AsTask[V any, RT RunnableTask[V]](rt RT) Task[V] {
-	if t, ok := any(rt).(func(context.Context) (V, error)); ok {
+	if t, ok := any(rt).(Task[V]); ok {
 		return t
 	}
 
-	t := any(rt).(func() (V, error))
+	if t, ok := convertFunc[func(context.Context) (V, error)](rt); ok {
+		return t
+	}
+
+	t, _ := convertFunc[func() (V, error)](rt)
 	return func(_ context.Context) (V, error) {
 		return t()
 	}
 }
 
+// convertFunc converts a function to the function type F.  The function may be a named
+// type whose underlying type is F, in which case reflect is used to convert it.
+func convertFunc[F any](f any) (F, bool) {
+	if converted, ok := f.(F); ok {
+		return converted, true
+	}
+
+	var (
+		converted F
+		target    = reflect.TypeFor[F]()
+		v         = reflect.ValueOf(f)
+	)
+
+	if v.Type().ConvertibleTo(target) {
+		converted = v.Convert(target).Interface().(F)
+		return converted, true
+	}
+
+	return converted, false
+}
+
 // SimpleTask is the underlying type for any task closure which doesn't return
 // a custom value.  Tasks of this type must be wrapped with a value, either in calling
 // code or via one of the convenience functions in this package.
@@ -40,11 +68,11 @@ type SimpleTask interface {
 // normalizeSimpleTask is performs the common work of coercing a SimpleTask
 // into a known closure that always accepts a context.
 func normalizeSimpleTask[T SimpleTask](raw T) func(context.Context) error {
-	if task, ok := any(raw).(func(context.Context) error); ok {
+	if task, ok := convertFunc[func(context.Context) error](raw); ok {
 		return task
 	}
 
-	f := any(raw).(func() error)
+	f, _ := convertFunc[func() error](raw)
 	return func(context.Context) error {
 		return f()
 	}
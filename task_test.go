@@ -75,9 +75,36 @@ func (suite *TaskSuite) testAsTaskWithContext() {
 	})
 }
 
+// namedTaskNoContext is a named RunnableTask type that doesn't accept a context.
+type namedTaskNoContext func() (int, error)
+
+func (suite *TaskSuite) testAsTaskNamed() {
+	suite.Run("Task", func() {
+		expectedCtx := suite.taskCtx()
+		task := AsTask[int](Task[int](func(actualCtx context.Context) (int, error) {
+			suite.Same(expectedCtx, actualCtx)
+			return 123, nil
+		}))
+
+		suite.Require().NotNil(task)
+		actual, actualErr := task(expectedCtx)
+		suite.NoError(actualErr)
+		suite.Equal(123, actual)
+	})
+
+	suite.Run("NoContext", func() {
+		task := AsTask[int](namedTaskNoContext(func() (int, error) { return 123, nil }))
+		suite.Require().NotNil(task)
+		actual, actualErr := task(context.Background())
+		suite.NoError(actualErr)
+		suite.Equal(123, actual)
+	})
+}
+
 func (suite *TaskSuite) TestAsTask() {
 	suite.Run("NoContext", suite.testAsTaskNoContext)
 	suite.Run("WithContext", suite.testAsTaskWithContext)
+	suite.Run("Named", suite.testAsTaskNamed)
 }
 
 func (suite *TaskSuite) testAddZeroNoContext() {
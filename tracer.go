@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// The names of the spans started by a Runner configured with WithTracer.
+const (
+	// SpanRun is the name of the span that covers an entire call to Run or RunAsync.
+	SpanRun = "retry.run"
+
+	// SpanAttempt is the name of the span that covers each task attempt.  It is a
+	// child of the SpanRun span.
+	SpanAttempt = "retry.attempt"
+)
+
+// The span attribute keys used by a Runner configured with WithTracer.
+const (
+	// AttrAttempts is the int number of attempts made during a run.
+	AttrAttempts = "retry.attempts"
+
+	// AttrRetries is the int number of retries.  For a run, this is the total.  For an
+	// attempt, this is the number of retries before it, so it is zero for the initial attempt.
+	AttrRetries = "retry.retries"
+
+	// AttrStopReason is the StopReason, as a string, for a run.
+	AttrStopReason = "retry.stop_reason"
+
+	// AttrSleep is the time.Duration waited before an attempt.  It is zero for the initial attempt.
+	AttrSleep = "retry.sleep"
+
+	// AttrNext is the time.Duration that will be waited before the next retry.  It is only
+	// set on an attempt that will be retried.
+	AttrNext = "retry.next"
+
+	// AttrCategory is the Category, as a string, of an attempt's error.
+	AttrCategory = "retry.category"
+)
+
+// Span is a single unit of traced work.  Spans are not used concurrently by a Runner.
+type Span interface {
+	// SetAttribute records a key/value pair on this span.  A Runner only uses string,
+	// int, and time.Duration values.
+	SetAttribute(key string, value any)
+
+	// End completes this span.  The error is nil if the span's work succeeded.
+	End(err error)
+}
+
+// Tracer starts spans for runs and attempts.  This interface is intentionally small, so
+// that adapters for tracing libraries are easy to write.  For example, with OpenTelemetry:
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, retry.Span) {
+//		ctx, span := t.tracer.Start(ctx, name)
+//		return ctx, otelSpan{span: span}
+//	}
+//
+// Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start begins a span with the given name.  The returned context must carry the span,
+	// so that spans started with it become children.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// WithTracer starts a span for each run and a child span for each attempt using the given
+// Tracer.  The context passed to the task carries the attempt span, so that any spans
+// the task starts are its children.
+//
+// If t is nil, no spans are started.
+func WithTracer[V any](t Tracer) RunnerOption[V] {
+	return runnerOptionFunc[V](func(r *runner[V]) error {
+		r.tracer = t
+		return nil
+	})
+}
+
+// startRunSpan begins the span for a run, if there is a Tracer.  The run state's parent
+// context is replaced with one that carries the span.
+func (r *runner[V]) startRunSpan(rs *runState[V]) {
+	if r.tracer != nil {
+		rs.parentCtx, rs.runSpan = r.tracer.Start(rs.parentCtx, SpanRun)
+	}
+}
+
+// endRunSpan completes the span for a run, if one was started.
+func (rs *runState[V]) endRunSpan(err error) {
+	if rs.runSpan != nil {
+		rs.runSpan.SetAttribute(AttrAttempts, rs.attempts)
+		rs.runSpan.SetAttribute(AttrRetries, max(rs.attempts-1, 0))
+		rs.runSpan.SetAttribute(AttrStopReason, rs.reason.String())
+		rs.runSpan.End(err)
+	}
+}
+
+// startAttemptSpan begins the span for an attempt, if there is a Tracer.  The returned
+// context is the one to pass to the task.
+func (r *runner[V]) startAttemptSpan(rs *runState[V], taskCtx context.Context, sleep time.Duration) context.Context {
+	if r.tracer == nil {
+		return taskCtx
+	}
+
+	attemptCtx, span := r.tracer.Start(taskCtx, SpanAttempt)
+	span.SetAttribute(AttrRetries, rs.retries)
+	span.SetAttribute(AttrSleep, sleep)
+	rs.attemptSpan = span
+	return attemptCtx
+}
+
+// endAttemptSpan completes the span for an attempt, if one was started.
+func (rs *runState[V]) endAttemptSpan(a Attempt[V]) {
+	if rs.attemptSpan != nil {
+		rs.attemptSpan.SetAttribute(AttrCategory, a.Category.String())
+		if a.Next > 0 {
+			rs.attemptSpan.SetAttribute(AttrNext, a.Next)
+		}
+
+		rs.attemptSpan.End(a.Err)
+		rs.attemptSpan = nil
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2023 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testSpanKey struct{}
+
+// testSpan records everything done to it.
+type testSpan struct {
+	name       string
+	parent     *testSpan
+	attributes map[string]any
+	ended      bool
+	err        error
+}
+
+func (ts *testSpan) SetAttribute(key string, value any) {
+	ts.attributes[key] = value
+}
+
+func (ts *testSpan) End(err error) {
+	ts.ended = true
+	ts.err = err
+}
+
+// testTracer records the spans it starts, in order.
+type testTracer struct {
+	lock  sync.Mutex
+	spans []*testSpan
+}
+
+func (tt *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	defer tt.lock.Unlock()
+	tt.lock.Lock()
+	span := &testSpan{
+		name:       name,
+		attributes: make(map[string]any),
+	}
+
+	span.parent, _ = ctx.Value(testSpanKey{}).(*testSpan)
+	tt.spans = append(tt.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+type TracerSuite struct {
+	CommonSuite
+}
+
+func (suite *TracerSuite) TestRetries() {
+	var (
+		testCtx, _ = suite.testCtx()
+		retryErr   = errors.New("should retry this")
+		tracer     = new(testTracer)
+		calls      int
+		runner     = suite.newRunner(
+			WithImmediateTimer[int](),
+			WithTracer[int](tracer),
+			WithPolicyFactory[int](Config{
+				Interval: 5 * time.Second,
+			}),
+		)
+	)
+
+	result, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		suite.assertTestCtx(ctx)
+		calls++
+
+		// the task's context carries the attempt span
+		span, _ := ctx.Value(testSpanKey{}).(*testSpan)
+		suite.Require().NotNil(span)
+		suite.Equal(SpanAttempt, span.name)
+		suite.Same(tracer.spans[len(tracer.spans)-1], span)
+		if calls < 3 {
+			return -1, retryErr
+		}
+
+		return 123, nil
+	})
+
+	suite.Require().NoError(err)
+	suite.Equal(123, result)
+	suite.Require().Len(tracer.spans, 4)
+
+	run := tracer.spans[0]
+	suite.Equal(SpanRun, run.name)
+	suite.Nil(run.parent)
+	suite.True(run.ended)
+	suite.NoError(run.err)
+	suite.Equal(
+		map[string]any{
+			AttrAttempts:   3,
+			AttrRetries:    2,
+			AttrStopReason: "success",
+		},
+		run.attributes,
+	)
+
+	for i, attempt := range tracer.spans[1:] {
+		suite.Equal(SpanAttempt, attempt.name)
+		suite.Same(run, attempt.parent)
+		suite.True(attempt.ended)
+		suite.Equal(i, attempt.attributes[AttrRetries])
+	}
+
+	suite.Equal(
+		map[string]any{
+			AttrRetries:  0,
+			AttrSleep:    time.Duration(0),
+			AttrCategory: "unknown",
+			AttrNext:     5 * time.Second,
+		},
+		tracer.spans[1].attributes,
+	)
+
+	suite.Same(retryErr, tracer.spans[2].err)
+	suite.Equal(5*time.Second, tracer.spans[2].attributes[AttrSleep])
+
+	suite.Equal(
+		map[string]any{
+			AttrRetries:  2,
+			AttrSleep:    5 * time.Second,
+			AttrCategory: "none",
+		},
+		tracer.spans[3].attributes,
+	)
+
+	suite.NoError(tracer.spans[3].err)
+}
+
+func (suite *TracerSuite) TestCircuitOpen() {
+	var (
+		testCtx, _ = suite.testCtx()
+		tracer     = new(testTracer)
+		cb         = NewCircuitBreaker(BreakerConfig{ConsecutiveFailures: 1})
+		runner     = suite.newRunner(
+			WithTracer[int](tracer),
+			WithCircuitBreaker[int](cb),
+		)
+	)
+
+	cb.record(0, true)
+	_, err := runner.Run(testCtx, func(context.Context) (int, error) {
+		suite.Fail("the task should not be called")
+		return 0, nil
+	})
+
+	suite.ErrorIs(err, ErrCircuitOpen)
+	suite.Require().Len(tracer.spans, 1)
+	suite.ErrorIs(tracer.spans[0].err, ErrCircuitOpen)
+	suite.Equal(0, tracer.spans[0].attributes[AttrAttempts])
+	suite.Equal("circuitOpen", tracer.spans[0].attributes[AttrStopReason])
+}
+
+func (suite *TracerSuite) TestNoTracer() {
+	testCtx, _ := suite.testCtx()
+	runner := suite.newRunner(WithTracer[int](nil))
+	result, err := runner.Run(testCtx, func(ctx context.Context) (int, error) {
+		suite.Nil(ctx.Value(testSpanKey{}))
+		return 123, nil
+	})
+
+	suite.NoError(err)
+	suite.Equal(123, result)
+}
+
+func TestTracer(t *testing.T) {
+	suite.Run(t, new(TracerSuite))
+}